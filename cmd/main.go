@@ -7,6 +7,7 @@
 package main
 
 import (
+    "flag"
     "fmt"
     "os"
     "github.com/ritankarsaha/git-tool/internal/cli"
@@ -15,6 +16,11 @@ import (
 )
 
 func main() {
+    if len(os.Args) > 1 {
+        runSubcommand(os.Args[1], os.Args[2:])
+        return
+    }
+
     // Check if Git is installed
     if _, err := git.RunCommand("git --version"); err != nil {
         fmt.Println(ui.FormatError("Git is not installed or not in PATH"))
@@ -35,4 +41,29 @@ func main() {
 
     // Start the CLI menu
     cli.StartMenu()
-}
\ No newline at end of file
+}
+
+// runSubcommand handles the commands that work outside the interactive
+// menu: login and logout
+func runSubcommand(name string, args []string) {
+    flags := flag.NewFlagSet(name, flag.ExitOnError)
+    host := flags.String("host", "", "GitHub host (default: the configured host)")
+
+    var run func(string) error
+    switch name {
+    case "login":
+        run = cli.Login
+    case "logout":
+        run = cli.Logout
+    default:
+        fmt.Println(ui.FormatError(fmt.Sprintf("Unknown command: %s", name)))
+        fmt.Println("Usage: githubber [login|logout] [--host HOST]")
+        os.Exit(2)
+    }
+
+    flags.Parse(args)
+    if err := run(*host); err != nil {
+        fmt.Println(ui.FormatError(fmt.Sprintf("%s failed: %v", name, err)))
+        os.Exit(1)
+    }
+}
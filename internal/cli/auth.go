@@ -0,0 +1,145 @@
+/*
+ * GitHubber - CLI Login
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Browser-based login with the OAuth device flow, and logout
+ */
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/ritankarsaha/git-tool/internal/config"
+	"github.com/ritankarsaha/git-tool/internal/github"
+	"github.com/ritankarsaha/git-tool/internal/ui"
+)
+
+// Login authorizes GitHubber for host (the default host if empty) with the
+// OAuth device flow and stores the resulting token, preferring the keyring
+// over the configuration file
+func Login(host string) error {
+	cfg := loadConfigOrDefault()
+	if host == "" {
+		host = cfg.DefaultHost()
+	}
+
+	clientID := os.Getenv("GITHUBBER_OAUTH_CLIENT_ID")
+	if clientID == "" {
+		clientID = cfg.GetHostConfig(host).OAuthClientID
+	}
+	if clientID == "" {
+		return fmt.Errorf("no OAuth app configured for %s: set github.oauth_client_id (or GITHUBBER_OAUTH_CLIENT_ID) to the client ID of an OAuth app with device flow enabled", host)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	flow := github.NewDeviceFlow(host, clientID, github.DefaultScopes)
+	code, err := flow.Start(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(ui.FormatInfo(fmt.Sprintf("First copy your one-time code: %s", code.UserCode)))
+	fmt.Println(ui.FormatInfo(fmt.Sprintf("Then open %s in your browser and enter it", code.VerificationURI)))
+	fmt.Println(ui.FormatInfo("Waiting for authorization (Ctrl+C to cancel)..."))
+
+	login, err := flow.Wait(ctx, code)
+	if err != nil {
+		return err
+	}
+
+	if missing := github.MissingScopes(login.Scopes, github.DefaultScopes); len(missing) > 0 {
+		fmt.Println(ui.FormatWarning(fmt.Sprintf("The token was granted without %s; some features may not work", strings.Join(missing, ", "))))
+	}
+
+	client, err := github.NewClientForHostWithToken(host, login.Token)
+	if err != nil {
+		return err
+	}
+	user, err := client.GetUser()
+	if err != nil {
+		return fmt.Errorf("the new token does not work: %w", err)
+	}
+
+	location, err := storeToken(cfg, host, login.Token)
+	if err != nil {
+		return err
+	}
+	fmt.Println(ui.FormatSuccess(fmt.Sprintf("Logged in to %s as %s (token stored in the %s)", host, user.GetLogin(), location)))
+
+	if credential, err := github.ResolveCredential(host); err == nil && credential.Token != login.Token {
+		fmt.Println(ui.FormatWarning(fmt.Sprintf("%s takes precedence over the new token", credential.Source)))
+	}
+	return nil
+}
+
+// storeToken keeps token in the keyring when one is available, otherwise
+// in the configuration file, and reports where it went
+func storeToken(cfg *config.Config, host, token string) (string, error) {
+	if github.KeyringAvailable() {
+		if err := github.StoreKeyringToken(host, token); err == nil {
+			return "keyring", nil
+		}
+	}
+
+	fmt.Println(ui.FormatWarning("No keyring available, storing the token in the configuration file (readable only by you)"))
+	if err := cfg.SetHostToken(host, token); err != nil {
+		return "", fmt.Errorf("failed to save token: %w", err)
+	}
+	return "configuration file", nil
+}
+
+// Logout removes the token GitHubber stored for host (the default host if
+// empty) from the keyring and configuration file
+func Logout(host string) error {
+	cfg := loadConfigOrDefault()
+	if host == "" {
+		host = cfg.DefaultHost()
+	}
+
+	// A keyring failure must not leave the token in the configuration file
+	if github.KeyringAvailable() {
+		if err := github.DeleteKeyringToken(host); err != nil {
+			fmt.Println(ui.FormatWarning(fmt.Sprintf("Could not remove the token from the keyring: %v", err)))
+		}
+	}
+	if cfg.GetHostConfig(host).Token != "" {
+		if err := cfg.SetHostToken(host, ""); err != nil {
+			return fmt.Errorf("failed to remove token: %w", err)
+		}
+	}
+
+	fmt.Println(ui.FormatSuccess(fmt.Sprintf("Removed the stored token for %s", host)))
+
+	// Device flow tokens can only be revoked with the OAuth app's secret,
+	// which a CLI cannot keep, so point the user at GitHub's settings page
+	revokeURL := fmt.Sprintf("https://%s/settings/applications", host)
+	if clientID := cfg.GetHostConfig(host).OAuthClientID; clientID != "" {
+		revokeURL = fmt.Sprintf("https://%s/settings/connections/applications/%s", host, clientID)
+	}
+	fmt.Println(ui.FormatInfo(fmt.Sprintf("To revoke the token itself, visit %s", revokeURL)))
+
+	if credential, err := github.ResolveCredential(host); err == nil {
+		fmt.Println(ui.FormatWarning(fmt.Sprintf("Still authenticated through %s", credential.Source)))
+	}
+	return nil
+}
+
+func handleLogin() {
+	host := GetInput(ui.FormatPrompt("Host to log in to (default: configured host): "))
+	if err := Login(host); err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Login failed: %v", err)))
+	}
+}
+
+func handleLogout() {
+	host := GetInput(ui.FormatPrompt("Host to log out from (default: configured host): "))
+	if err := Logout(host); err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Logout failed: %v", err)))
+	}
+}
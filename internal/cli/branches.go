@@ -0,0 +1,133 @@
+/*
+ * GitHubber - CLI Branch Maintenance
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Default branch resolution, merged branch cleanup and default branch sync
+ */
+
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ritankarsaha/git-tool/internal/config"
+	"github.com/ritankarsaha/git-tool/internal/git"
+	"github.com/ritankarsaha/git-tool/internal/github"
+	"github.com/ritankarsaha/git-tool/internal/ui"
+)
+
+// resolveDefaultBranch determines the repository's default branch. It
+// prefers <remote>/HEAD of the base remote (upstream in fork setups), then
+// asks the GitHub API (recording the answer as <remote>/HEAD), and falls
+// back to the configured default branch.
+func resolveDefaultBranch(cfg *config.Config) string {
+	remote, err := git.BaseRemote()
+	if err == nil {
+		if branch, err := git.RemoteDefaultBranch(remote); err == nil {
+			return branch
+		}
+		if branch := githubDefaultBranch(remote); branch != "" {
+			git.SetRemoteDefaultBranch(remote, branch)
+			return branch
+		}
+	}
+
+	if cfg != nil && cfg.Git.DefaultBranch != "" {
+		return cfg.Git.DefaultBranch
+	}
+	return "main"
+}
+
+// githubDefaultBranch looks up the default branch of the GitHub repository
+// behind remote, returning "" when it cannot be determined
+func githubDefaultBranch(remote string) string {
+	repo, err := githubRepository(remote)
+	if err != nil {
+		return ""
+	}
+	client, err := github.NewClientForHost(repo.Host)
+	if err != nil {
+		return ""
+	}
+	repository, err := client.GetRepository(repo.Owner, repo.Name)
+	if err != nil {
+		return ""
+	}
+	return repository.DefaultBranch
+}
+
+func loadConfigOrDefault() *config.Config {
+	cfg, err := config.Load()
+	if err != nil {
+		return config.GetDefaultConfig()
+	}
+	return cfg
+}
+
+func handleCleanupBranches() {
+	defaultBranch := resolveDefaultBranch(loadConfigOrDefault())
+
+	base := defaultBranch
+	if remote, err := git.BaseRemote(); err == nil {
+		if err := git.Fetch(remote); err != nil {
+			fmt.Println(ui.FormatWarning(fmt.Sprintf("Could not fetch %s; the list below may be out of date", remote)))
+		}
+		base = remote + "/" + defaultBranch
+	}
+
+	branches, err := git.MergedBranches(base, defaultBranch)
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Error listing merged branches: %v", err)))
+		return
+	}
+	if len(branches) == 0 {
+		fmt.Println(ui.FormatInfo(fmt.Sprintf("No local branches are merged into %s", base)))
+		return
+	}
+
+	fmt.Println(ui.FormatInfo(fmt.Sprintf("Branches merged into %s:", base)))
+	for _, branch := range branches {
+		fmt.Printf("  %s\n", branch)
+	}
+	if !GetConfirmation(ui.FormatPrompt(fmt.Sprintf("Delete these %d branches? (y/N): ", len(branches)))) {
+		fmt.Println(ui.FormatInfo("No branches deleted"))
+		return
+	}
+
+	deleted := 0
+	for _, branch := range branches {
+		if err := git.DeleteMergedBranch(branch); err != nil {
+			fmt.Println(ui.FormatWarning(fmt.Sprintf("%v", err)))
+			continue
+		}
+		deleted++
+	}
+	fmt.Println(ui.FormatSuccess(fmt.Sprintf("Deleted %d merged branches", deleted)))
+}
+
+func handleSyncDefaultBranch() {
+	remote, err := git.BaseRemote()
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Cannot sync: %v", err)))
+		return
+	}
+	defaultBranch := resolveDefaultBranch(loadConfigOrDefault())
+
+	if err := git.FastForwardBranch(remote, defaultBranch); err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Error syncing %s: %v", defaultBranch, err)))
+		return
+	}
+	fmt.Println(ui.FormatSuccess(fmt.Sprintf("%s is up to date with %s/%s", defaultBranch, remote, defaultBranch)))
+
+	current, err := git.CurrentBranch()
+	if err != nil || current == defaultBranch {
+		return
+	}
+	if !GetConfirmation(ui.FormatPrompt(fmt.Sprintf("Rebase %s onto %s? (y/N): ", current, defaultBranch))) {
+		return
+	}
+	if err := git.RebaseCurrentBranch(defaultBranch); err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("%v", err)))
+		return
+	}
+	fmt.Println(ui.FormatSuccess(fmt.Sprintf("Rebased %s onto %s", current, defaultBranch)))
+}
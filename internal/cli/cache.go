@@ -0,0 +1,59 @@
+/*
+ * GitHubber - CLI API Cache Settings
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Offline mode and maintenance of the GitHub API response cache
+ */
+
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ritankarsaha/git-tool/internal/config"
+	"github.com/ritankarsaha/git-tool/internal/github"
+	"github.com/ritankarsaha/git-tool/internal/ui"
+)
+
+func setCachePreferences(cfg *config.Config) {
+	cacheDir, err := config.GetCacheDir()
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Failed to locate cache: %v", err)))
+		return
+	}
+
+	stats, err := github.GetCacheStats(cacheDir)
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Failed to read cache: %v", err)))
+		return
+	}
+	fmt.Println(ui.FormatInfo(fmt.Sprintf("%d cached responses (%.1f KB) in %s", stats.Entries, float64(stats.Bytes)/1024, cacheDir)))
+	fmt.Println(ui.FormatInfo(fmt.Sprintf("Offline mode: %t", cfg.GitHub.Offline)))
+
+	fmt.Println("1. Toggle offline mode")
+	fmt.Println("2. Clear cache")
+	fmt.Println("3. Back")
+
+	switch GetInput(ui.FormatPrompt("Enter your choice (1-3): ")) {
+	case "1":
+		cfg.GitHub.Offline = !cfg.GitHub.Offline
+		if err := cfg.Save(); err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("Failed to save configuration: %v", err)))
+			return
+		}
+		if cfg.GitHub.Offline {
+			fmt.Println(ui.FormatSuccess("Offline mode enabled, GitHub data is served from the cache only"))
+		} else {
+			fmt.Println(ui.FormatSuccess("Offline mode disabled"))
+		}
+	case "2":
+		if !GetConfirmation(ui.FormatPrompt(fmt.Sprintf("Delete %d cached responses? (y/N): ", stats.Entries))) {
+			fmt.Println(ui.FormatInfo("Cache left untouched"))
+			return
+		}
+		if err := github.ClearCache(cacheDir); err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("Failed to clear cache: %v", err)))
+			return
+		}
+		fmt.Println(ui.FormatSuccess("Cache cleared"))
+	}
+}
@@ -0,0 +1,138 @@
+/*
+ * GitHubber - CLI Changelog Generator
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Interactive changelog generation from commit history
+ */
+
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ritankarsaha/git-tool/internal/git"
+	"github.com/ritankarsaha/git-tool/internal/github"
+	"github.com/ritankarsaha/git-tool/internal/ui"
+)
+
+func handleChangelog() {
+	defaultFrom, defaultTo, err := git.DefaultChangelogRange()
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Error determining changelog range: %v", err)))
+		return
+	}
+
+	fromLabel := defaultFrom
+	if fromLabel == "" {
+		fromLabel = "beginning of history"
+	}
+	from := GetInput(ui.FormatPrompt(fmt.Sprintf("Enter start ref (default: %s): ", fromLabel)))
+	if from == "" {
+		from = defaultFrom
+	}
+	to := GetInput(ui.FormatPrompt(fmt.Sprintf("Enter end ref (default: %s): ", defaultTo)))
+	if to == "" {
+		to = defaultTo
+	}
+
+	grouping := git.GroupByType
+	if GetInput(ui.FormatPrompt("Group by (type/label, default: type): ")) == "label" {
+		grouping = git.GroupByLabel
+	}
+	format := GetInput(ui.FormatPrompt("Output format (markdown/json, default: markdown): "))
+	if format == "" {
+		format = "markdown"
+	}
+	if format != "markdown" && format != "json" {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Unknown format: %s", format)))
+		return
+	}
+
+	entries, err := git.ChangelogEntries(from, to)
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Error reading commits: %v", err)))
+		return
+	}
+	if len(entries) == 0 {
+		fmt.Println(ui.FormatInfo("No commits in range"))
+		return
+	}
+
+	repo := changelogRepository()
+	if grouping == git.GroupByLabel {
+		if err := applyPullRequestLabels(entries, repo); err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("Failed to fetch pull request labels: %v", err)))
+			return
+		}
+	}
+
+	title := to
+	if to == "HEAD" {
+		title = "Unreleased"
+	}
+	changelog := git.NewChangelog(title, from, to, entries, grouping)
+	if repo != nil {
+		changelog.RepoURL = repo.WebURL()
+	}
+
+	if format == "json" {
+		output, err := changelog.JSON()
+		if err != nil {
+			fmt.Println(ui.FormatError(err.Error()))
+			return
+		}
+		fmt.Println(output)
+		return
+	}
+
+	markdown := changelog.Markdown()
+	path := GetInput(ui.FormatPrompt("Prepend to file (e.g. CHANGELOG.md, press enter to print): "))
+	if path == "" {
+		fmt.Println(markdown)
+		return
+	}
+	if err := git.PrependChangelog(path, markdown); err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Error writing changelog: %v", err)))
+		return
+	}
+	fmt.Println(ui.FormatSuccess(fmt.Sprintf("Changelog for %s written to %s", title, path)))
+}
+
+// changelogRepository returns the GitHub repository of the current
+// repository, or nil when its remote is not on GitHub
+func changelogRepository() *github.RepoRef {
+	repoInfo, err := git.GetRepositoryInfo()
+	if err != nil {
+		return nil
+	}
+	repo, err := parseGitHubRepository(repoInfo.URL)
+	if err != nil {
+		return nil
+	}
+	return repo
+}
+
+// applyPullRequestLabels looks up the labels of each entry's pull request
+func applyPullRequestLabels(entries []git.ChangelogEntry, repo *github.RepoRef) error {
+	if repo == nil {
+		return fmt.Errorf("the repository remote is not on GitHub")
+	}
+	client, err := github.NewClientForHost(repo.Host)
+	if err != nil {
+		return err
+	}
+
+	labels := make(map[int][]string)
+	for i := range entries {
+		number := entries[i].PullRequest
+		if number == 0 {
+			continue
+		}
+		if _, ok := labels[number]; !ok {
+			if labels[number], err = client.GetIssueLabels(repo.Owner, repo.Name, number); err != nil {
+				return err
+			}
+		}
+		entries[i].Labels = labels[number]
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+/*
+ * GitHubber - CLI Pull Request Checkout
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Checking out pull requests locally, from the base repository or the author's fork
+ */
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ritankarsaha/git-tool/internal/git"
+	"github.com/ritankarsaha/git-tool/internal/github"
+	"github.com/ritankarsaha/git-tool/internal/ui"
+)
+
+func handleCheckoutPR() {
+	repo, client, ok := openGitHubRepository()
+	if !ok {
+		return
+	}
+	number, ok := selectPullRequest(client, repo)
+	if !ok {
+		return
+	}
+
+	pr, err := client.GetPullRequest(repo.Owner, repo.Name, number)
+	if err != nil {
+		printGitHubError("Failed to get pull request", err)
+		return
+	}
+	baseRemote, err := git.BaseRemote()
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Cannot check out pull request: %v", err)))
+		return
+	}
+
+	opts, err := pullRequestCheckout(pr, baseRemote)
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("%v", err)))
+		return
+	}
+	opts.Branch = pullRequestBranchName(pr, opts)
+
+	fmt.Println(ui.FormatInfo(fmt.Sprintf("Fetching #%d %s...", pr.Number, pr.Title)))
+	result, err := git.CheckoutPullRequest(opts)
+	var diverged *git.BranchDivergedError
+	if errors.As(err, &diverged) {
+		fmt.Println(ui.FormatWarning(fmt.Sprintf("%v; the pull request was probably force-pushed", err)))
+		if !GetConfirmation(ui.FormatPrompt(fmt.Sprintf("Reset %s to the pull request? Local commits on it will be lost (y/N): ", opts.Branch))) {
+			fmt.Println(ui.FormatInfo(fmt.Sprintf("%s was left unchanged", opts.Branch)))
+			return
+		}
+		opts.Force = true
+		result, err = git.CheckoutPullRequest(opts)
+	}
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("%v", err)))
+		return
+	}
+
+	switch {
+	case result.Created:
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("Checked out #%d as %s", pr.Number, result.Branch)))
+	case result.UpToDate():
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("Switched to %s, already up to date with #%d", result.Branch, pr.Number)))
+	default:
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("Updated %s to #%d (%s..%s)", result.Branch, pr.Number, shortHash(result.From), shortHash(result.To))))
+	}
+
+	if opts.RemoteBranch != "" {
+		fmt.Println(ui.FormatInfo(fmt.Sprintf("%s tracks %s/%s; pushing it updates the pull request", result.Branch, opts.Remote, opts.RemoteBranch)))
+	} else {
+		fmt.Println(ui.FormatInfo(fmt.Sprintf("%s is read-only: pull to get new commits, but pushes cannot update the pull request", result.Branch)))
+	}
+}
+
+// pullRequestCheckout decides where to fetch a pull request from. Open pull
+// requests whose head repository is a configured remote, or a fork that
+// allows edits from maintainers, are fetched from their head branch so the
+// local branch can push to it. A remote is added for such forks. All others
+// are fetched read-only from refs/pull/<n>/head of the base remote.
+func pullRequestCheckout(pr *github.PullRequest, baseRemote string) (git.PullRequestCheckout, error) {
+	opts := git.PullRequestCheckout{Number: pr.Number, Remote: baseRemote}
+	if pr.State != "open" || pr.HeadRepo == "" {
+		// The head branch may be gone, refs/pull/<n>/head never is
+		return opts, nil
+	}
+
+	if remote := remoteForRepository(pr.HeadRepo); remote != "" {
+		opts.Remote, opts.RemoteBranch = remote, pr.Head
+		return opts, nil
+	}
+	if !pr.MaintainerCanModify {
+		return opts, nil
+	}
+
+	owner, name, _ := strings.Cut(pr.HeadRepo, "/")
+	remote := owner
+	if git.HasRemote(remote) {
+		remote = owner + "-" + name
+	}
+	url := pr.HeadCloneURL
+	if baseURL, err := git.RemoteURL(baseRemote); err == nil && !strings.HasPrefix(baseURL, "http") && pr.HeadSSHURL != "" {
+		url = pr.HeadSSHURL
+	}
+	if err := git.AddRemote(remote, url); err != nil {
+		return opts, err
+	}
+	fmt.Println(ui.FormatInfo(fmt.Sprintf("Added remote %s for %s", remote, pr.HeadRepo)))
+
+	opts.Remote, opts.RemoteBranch = remote, pr.Head
+	return opts, nil
+}
+
+// remoteForRepository returns the configured remote that points at the
+// GitHub repository "owner/name", or "" if there is none
+func remoteForRepository(fullName string) string {
+	remotes, err := git.ListRemotes()
+	if err != nil {
+		return ""
+	}
+	for _, remote := range remotes {
+		ref, err := parseGitHubRepository(remote.FetchURL)
+		if err == nil && strings.EqualFold(ref.Owner+"/"+ref.Name, fullName) {
+			return remote.Name
+		}
+	}
+	return ""
+}
+
+// pullRequestBranchName picks the local branch for a pull request: the
+// head branch's name, unless that would clash with the base branch or with
+// a local branch tracking something else, in which case "pr-<n>"
+func pullRequestBranchName(pr *github.PullRequest, opts git.PullRequestCheckout) string {
+	name := pr.Head
+	if name == "" || name == pr.Base {
+		return fmt.Sprintf("pr-%d", pr.Number)
+	}
+	if _, err := git.BranchCommit(name); err != nil {
+		return name
+	}
+	// Upstream strips refs/heads/ but leaves refs/pull/<n>/head intact
+	if remote, ref, ok := git.Upstream(name); ok && remote == opts.Remote && (ref == opts.RemoteBranch || ref == opts.MergeRef()) {
+		return name
+	}
+	return fmt.Sprintf("pr-%d", pr.Number)
+}
@@ -0,0 +1,129 @@
+/*
+ * GitHubber - CLI Commit Composer
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Guided Conventional Commits composer with message validation
+ */
+
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ritankarsaha/git-tool/internal/config"
+	"github.com/ritankarsaha/git-tool/internal/git"
+	"github.com/ritankarsaha/git-tool/internal/ui"
+)
+
+func handleCommit() {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Println(ui.FormatWarning(fmt.Sprintf("Failed to load configuration, using defaults: %v", err)))
+		cfg = config.GetDefaultConfig()
+	}
+	rules := commitRules(cfg)
+
+	message, ok := composeCommitMessage(rules)
+	if !ok {
+		fmt.Println(ui.FormatInfo("Commit cancelled"))
+		return
+	}
+
+	if err := git.Commit(message); err != nil {
+		fmt.Printf("❌ Error committing changes: %v\n", err)
+		return
+	}
+	fmt.Println("✅ Changes committed successfully!")
+
+	autoPush(cfg)
+}
+
+// commitRules converts the configured commit rules for validation
+func commitRules(cfg *config.Config) git.CommitRules {
+	rules := cfg.Git.CommitRules
+	return git.CommitRules{
+		MaxSubjectLength: rules.SubjectMaxLength,
+		AllowedTypes:     rules.AllowedTypes,
+		AllowedScopes:    rules.AllowedScopes,
+		RequireScope:     rules.RequireScope,
+		CheckImperative:  !rules.DisableMoodCheck,
+	}
+}
+
+// composeCommitMessage walks the user through writing a commit message and
+// reports false if they abort. Leaving the type empty switches to a
+// free-form message, which only has its subject, length and issue
+// references checked.
+func composeCommitMessage(rules git.CommitRules) (string, bool) {
+	types := rules.AllowedTypes
+	if len(types) == 0 {
+		types = git.ConventionalTypes()
+	}
+
+	fmt.Println(ui.FormatInfo("Commit Composer"))
+	fmt.Println(ui.FormatInfo("Types: " + strings.Join(types, ", ")))
+
+	for {
+		var msg git.CommitMessage
+		msg.Type = GetInput(ui.FormatPrompt("Type (press enter for a free-form message): "))
+		if msg.Type == "" {
+			message := GetMultilineInput(ui.FormatPrompt("Enter commit message (finish with a line containing only '.'):"))
+			if message == "" {
+				fmt.Println(ui.FormatError("Commit message cannot be empty"))
+				return "", false
+			}
+			problems := git.ValidateCommitMessage(git.ParseCommitMessage(message), rules)
+			if len(problems) == 0 {
+				return message, true
+			}
+			if commit, done := resolveCommitProblems(problems); done {
+				return message, commit
+			}
+			continue
+		}
+
+		scopeHint := "optional"
+		if len(rules.AllowedScopes) > 0 {
+			scopeHint = strings.Join(rules.AllowedScopes, ", ")
+		}
+		msg.Scope = GetInput(ui.FormatPrompt(fmt.Sprintf("Scope (%s): ", scopeHint)))
+		msg.Subject = GetInput(ui.FormatPrompt("Subject (imperative, e.g. \"add stash browser\"): "))
+		msg.Body = GetMultilineInput(ui.FormatPrompt("Body (optional, finish with a line containing only '.'):"))
+		if GetConfirmation(ui.FormatPrompt("Is this a breaking change? (y/N): ")) {
+			msg.Breaking = true
+			msg.BreakingChange = GetInput(ui.FormatPrompt("Describe the breaking change: "))
+		}
+		msg.Closes = strings.Fields(GetInput(ui.FormatPrompt("Issues this closes (e.g. #12 #15, press enter for none): ")))
+		msg.References = strings.Fields(GetInput(ui.FormatPrompt("Related issues (press enter for none): ")))
+
+		message := msg.String()
+		fmt.Println(ui.FormatBox(strings.TrimSpace(message)))
+
+		problems := git.ValidateCommitMessage(msg, rules)
+		if len(problems) == 0 {
+			if GetConfirmation(ui.FormatPrompt("Create this commit? (y/N): ")) {
+				return message, true
+			}
+			return "", false
+		}
+		if commit, done := resolveCommitProblems(problems); done {
+			return message, commit
+		}
+	}
+}
+
+// resolveCommitProblems shows the problems found in a commit message and
+// asks what to do. done is false when the user wants to edit the message;
+// otherwise commit reports whether to commit it anyway.
+func resolveCommitProblems(problems []string) (commit, done bool) {
+	for _, problem := range problems {
+		fmt.Println(ui.FormatWarning(problem))
+	}
+	switch strings.ToLower(GetInput(ui.FormatPrompt("(e)dit, (c)ommit anyway or (a)bort? [e]: "))) {
+	case "c", "commit":
+		return true, true
+	case "a", "abort":
+		return false, true
+	}
+	return false, false
+}
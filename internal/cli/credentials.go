@@ -0,0 +1,81 @@
+/*
+ * GitHubber - CLI Credentials
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Diagnostics showing where the GitHub token comes from
+ */
+
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ritankarsaha/git-tool/internal/github"
+	"github.com/ritankarsaha/git-tool/internal/ui"
+)
+
+// defaultGitHubHost returns the host of the current repository's GitHub
+// remote, or "" (the configured default host) outside of one
+func defaultGitHubHost() string {
+	if repo, err := baseGitHubRepository(); err == nil {
+		return repo.Host
+	}
+	return ""
+}
+
+func diagnoseCredentials() {
+	host := defaultGitHubHost()
+	hostLabel := host
+	if hostLabel == "" {
+		hostLabel = "default host"
+	}
+	if answer := GetInput(ui.FormatPrompt(fmt.Sprintf("Host to check (default: %s): ", hostLabel))); answer != "" {
+		host = answer
+	}
+
+	credential, checks := github.DiagnoseCredentials(host)
+
+	fmt.Println(ui.FormatInfo("Credential sources (in order of precedence)"))
+	for _, check := range checks {
+		switch {
+		case credential != nil && check.Source == credential.Source:
+			fmt.Printf("%s %s: token found (in use)\n", ui.IconSuccess, check.Source)
+		case check.Found:
+			fmt.Printf("%s %s: token found (shadowed)\n", ui.IconInfo, check.Source)
+		case check.Err != nil:
+			fmt.Printf("%s %s: unavailable (%v)\n", ui.IconWarning, check.Source, check.Err)
+		default:
+			fmt.Printf("   %s: no token\n", check.Source)
+		}
+	}
+
+	if credential == nil {
+		fmt.Println(ui.FormatError("No GitHub token found"))
+		fmt.Println(ui.FormatInfo("Set GITHUB_TOKEN, run 'gh auth login', or save a token in Settings"))
+		return
+	}
+
+	fmt.Println(ui.FormatInfo(fmt.Sprintf("Using %s for %s from %s", github.MaskToken(credential.Token), credential.Host, credential.Source)))
+
+	client, err := github.NewClientForHost(credential.Host)
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Failed to create GitHub client: %v", err)))
+		return
+	}
+	user, err := client.GetUser()
+	if err != nil {
+		printGitHubError("Token check failed", err)
+		return
+	}
+	fmt.Println(ui.FormatSuccess(fmt.Sprintf("Authenticated as %s", user.GetLogin())))
+
+	scopes, ok, err := client.TokenScopes()
+	if err != nil || !ok {
+		return
+	}
+	if missing := github.MissingScopes(scopes, github.DefaultScopes); len(missing) > 0 {
+		fmt.Println(ui.FormatWarning(fmt.Sprintf("Token scopes: %s (missing %s)", strings.Join(scopes, ", "), strings.Join(missing, ", "))))
+	} else {
+		fmt.Println(ui.FormatInfo(fmt.Sprintf("Token scopes: %s", strings.Join(scopes, ", "))))
+	}
+}
@@ -0,0 +1,146 @@
+/*
+ * GitHubber - CLI Discard and Clean
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Discarding changes and cleaning untracked files with previews and confirmation
+ */
+
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ritankarsaha/git-tool/internal/git"
+	"github.com/ritankarsaha/git-tool/internal/ui"
+)
+
+func handleDiscard() {
+	status, err := git.RunCommand("git status --short")
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Error reading status: %v", err)))
+		return
+	}
+	if status == "" {
+		fmt.Println(ui.FormatInfo("No changes to discard"))
+		return
+	}
+	fmt.Println(ui.FormatInfo("Current changes:\n" + status))
+
+	paths := strings.Fields(GetInput(ui.FormatPrompt("Enter files to discard (space-separated, '.' for all): ")))
+	if len(paths) == 0 {
+		fmt.Println(ui.FormatError("No files given"))
+		return
+	}
+
+	fmt.Println("1. Unstaged changes (working tree)")
+	fmt.Println("2. Staged changes (unstage only)")
+	fmt.Println("3. Both staged and unstaged changes")
+	opts := git.DiscardOptions{Paths: paths}
+	switch GetInput(ui.FormatPrompt("What should be discarded? (1-3): ")) {
+	case "1":
+		opts.Worktree = true
+	case "2":
+		opts.Staged = true
+	case "3":
+		opts.Staged, opts.Worktree = true, true
+	default:
+		fmt.Println(ui.FormatError("Invalid choice"))
+		return
+	}
+
+	preview, err := git.PreviewDiscard(opts)
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("%v", err)))
+		return
+	}
+	if preview == "" {
+		fmt.Println(ui.FormatInfo("The selected files have no such changes"))
+		return
+	}
+	fmt.Println(ui.FormatWarning("The following changes will be discarded:"))
+	fmt.Println(preview)
+
+	if !GetConfirmation(ui.FormatPrompt("Discard these changes? (y/N): ")) {
+		fmt.Println(ui.FormatInfo("Discard cancelled"))
+		return
+	}
+	if opts.Worktree && !takeSafetyStash("GitHubber: before discard", false, false) {
+		return
+	}
+
+	if err := git.DiscardChanges(opts); err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Error discarding changes: %v", err)))
+		return
+	}
+	fmt.Println(ui.FormatSuccess("Changes discarded"))
+}
+
+func handleClean() {
+	opts := git.CleanOptions{
+		Directories: GetConfirmation(ui.FormatPrompt("Include untracked directories? (y/N): ")),
+	}
+
+	fmt.Println("1. Untracked files only")
+	fmt.Println("2. Untracked and ignored files")
+	fmt.Println("3. Ignored files only")
+	switch GetInput(ui.FormatPrompt("Files to remove (default: 1): ")) {
+	case "", "1":
+	case "2":
+		opts.Ignored = true
+	case "3":
+		opts.OnlyIgnored = true
+	default:
+		fmt.Println(ui.FormatError("Invalid choice"))
+		return
+	}
+	opts.Paths = strings.Fields(GetInput(ui.FormatPrompt("Limit to paths (space-separated, or press enter for all): ")))
+
+	preview, err := git.PreviewClean(opts)
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("%v", err)))
+		return
+	}
+	if len(preview) == 0 {
+		fmt.Println(ui.FormatInfo("Nothing to clean"))
+		return
+	}
+	fmt.Println(ui.FormatWarning(fmt.Sprintf("The following %d paths will be permanently deleted:", len(preview))))
+	for _, path := range preview {
+		fmt.Printf("  %s\n", path)
+	}
+
+	if !GetConfirmation(ui.FormatPrompt("Delete these files? (y/N): ")) {
+		fmt.Println(ui.FormatInfo("Clean cancelled"))
+		return
+	}
+	if !takeSafetyStash("GitHubber: before clean", true, opts.Ignored || opts.OnlyIgnored) {
+		return
+	}
+
+	removed, err := git.Clean(opts)
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Error cleaning files: %v", err)))
+		return
+	}
+	fmt.Println(ui.FormatSuccess(fmt.Sprintf("Removed %d paths", len(removed))))
+}
+
+// takeSafetyStash offers to snapshot the current changes into the stash and
+// reports whether the operation should continue
+func takeSafetyStash(message string, untracked, ignored bool) bool {
+	if !GetConfirmation(ui.FormatPrompt("Take a safety stash snapshot first? (y/N): ")) {
+		return true
+	}
+
+	ref, err := git.SafetyStash(message, untracked, ignored)
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("%v", err)))
+		return false
+	}
+	if ref == "" {
+		fmt.Println(ui.FormatInfo("Nothing to snapshot"))
+		return true
+	}
+	fmt.Println(ui.FormatSuccess(fmt.Sprintf("Snapshot saved as %s; restore it from the Stash Browser", ref)))
+	return true
+}
@@ -0,0 +1,131 @@
+/*
+ * GitHubber - CLI Fetch
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Fetching one or all remotes with live progress and an update summary
+ */
+
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ritankarsaha/git-tool/internal/git"
+	"github.com/ritankarsaha/git-tool/internal/ui"
+)
+
+// fetchProgressDisplay redraws a single status line showing the progress
+// of every remote being fetched
+type fetchProgressDisplay struct {
+	mu       sync.Mutex
+	progress map[string]git.FetchProgress
+}
+
+func newFetchProgressDisplay() *fetchProgressDisplay {
+	return &fetchProgressDisplay{progress: make(map[string]git.FetchProgress)}
+}
+
+// update records p and redraws the status line
+func (d *fetchProgressDisplay) update(p git.FetchProgress) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.progress[p.Remote] = p
+	remotes := make([]string, 0, len(d.progress))
+	for remote := range d.progress {
+		remotes = append(remotes, remote)
+	}
+	sort.Strings(remotes)
+
+	parts := make([]string, 0, len(remotes))
+	for _, remote := range remotes {
+		progress := d.progress[remote]
+		parts = append(parts, ui.FormatProgressBar(fmt.Sprintf("%s: %s", remote, progress.Phase), progress.Percent, 20))
+	}
+	fmt.Printf("\r\033[K%s", strings.Join(parts, "  "))
+}
+
+// finish ends the status line if anything was drawn
+func (d *fetchProgressDisplay) finish() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.progress) > 0 {
+		fmt.Println()
+	}
+}
+
+func handleFetch() {
+	fmt.Println("1. Fetch one remote")
+	fmt.Println("2. Fetch all remotes")
+	all := false
+	switch GetInput(ui.FormatPrompt("Enter your choice (default: 1): ")) {
+	case "", "1":
+	case "2":
+		all = true
+	default:
+		fmt.Println(ui.FormatError("Invalid choice"))
+		return
+	}
+
+	var remote string
+	if !all {
+		var ok bool
+		if remote, ok = selectRemote("Select remote"); !ok {
+			return
+		}
+	}
+
+	display := newFetchProgressDisplay()
+	opts := git.FetchOptions{
+		Prune:    GetConfirmation(ui.FormatPrompt("Prune branches deleted on the remote? (y/N): ")),
+		Progress: display.update,
+	}
+	if opts.Prune {
+		opts.PruneTags = GetConfirmation(ui.FormatPrompt("Also prune tags deleted on the remote? (y/N): "))
+	}
+
+	var results []git.FetchResult
+	if all {
+		var err error
+		if results, err = git.FetchAll(opts); err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("Error fetching updates: %v", err)))
+			return
+		}
+	} else {
+		results = []git.FetchResult{git.FetchRemote(remote, opts)}
+	}
+	display.finish()
+
+	for _, result := range results {
+		printFetchResult(result)
+	}
+}
+
+// printFetchResult summarises the ref updates of one fetched remote
+func printFetchResult(result git.FetchResult) {
+	if result.Err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("%v", result.Err)))
+		return
+	}
+	if len(result.Updates) == 0 {
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("%s: already up to date", result.Remote)))
+		return
+	}
+
+	counts := make(map[git.RefUpdateKind]int)
+	for _, update := range result.Updates {
+		counts[update.Kind]++
+	}
+	fmt.Println(ui.FormatSuccess(fmt.Sprintf("%s: %d updated, %d created, %d deleted",
+		result.Remote, counts[git.RefUpdated]+counts[git.RefForced], counts[git.RefCreated], counts[git.RefDeleted])))
+
+	for _, update := range result.Updates {
+		line := fmt.Sprintf("   %-8s %s", update.Kind, update.Ref)
+		if update.Kind == git.RefUpdated || update.Kind == git.RefForced {
+			line += " (" + update.Summary + ")"
+		}
+		fmt.Println(line)
+	}
+}
@@ -10,13 +10,80 @@ import (
     "bufio"
     "fmt"
     "os"
+    "os/exec"
     "strings"
 )
 
+// stdin is shared by all prompts so buffered input is never lost between them
+var stdin = bufio.NewReader(os.Stdin)
+
 // GetInput prompts the user for input and returns the trimmed response
 func GetInput(prompt string) string {
     fmt.Print(prompt)
-    reader := bufio.NewReader(os.Stdin)
-    input, _ := reader.ReadString('\n')
+    input, _ := stdin.ReadString('\n')
     return strings.TrimSpace(input)
-}
\ No newline at end of file
+}
+
+// GetSecretInput prompts for a value such as a token without echoing it
+// when reading from a terminal
+func GetSecretInput(prompt string) string {
+    info, err := os.Stdin.Stat()
+    if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+        return GetInput(prompt)
+    }
+
+    if err := stty("-echo"); err != nil {
+        return GetInput(prompt)
+    }
+    defer stty("echo")
+
+    input := GetInput(prompt)
+    fmt.Println()
+    return input
+}
+
+// stty changes terminal settings of stdin
+func stty(args ...string) error {
+    cmd := exec.Command("stty", args...)
+    cmd.Stdin = os.Stdin
+    return cmd.Run()
+}
+
+// GetMultilineInput prompts the user for text spanning several lines. Input
+// ends at a line containing only "." or at end of input; blank lines are kept.
+func GetMultilineInput(prompt string) string {
+    return strings.TrimSpace(readLines(prompt))
+}
+
+// GetCodeInput is like GetMultilineInput but keeps the indentation of the
+// lines, trimming only blank lines at the start and end
+func GetCodeInput(prompt string) string {
+    return strings.Trim(readLines(prompt), "\n")
+}
+
+// readLines prompts for lines until a line containing only "." or end of input
+func readLines(prompt string) string {
+    fmt.Println(prompt)
+    var lines []string
+    for {
+        line, err := stdin.ReadString('\n')
+        line = strings.TrimRight(line, "\r\n")
+        if line == "." {
+            break
+        }
+        if line != "" || err == nil {
+            lines = append(lines, line)
+        }
+        if err != nil {
+            break
+        }
+    }
+    return strings.Join(lines, "\n")
+}
+
+// GetConfirmation prompts the user with a yes/no question and reports
+// whether they answered yes
+func GetConfirmation(prompt string) bool {
+    answer := strings.ToLower(GetInput(prompt))
+    return answer == "y" || answer == "yes"
+}
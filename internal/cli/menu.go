@@ -9,6 +9,7 @@ package cli
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/ritankarsaha/git-tool/internal/config"
@@ -18,6 +19,10 @@ import (
 )
 
 func StartMenu() {
+	if cfg, err := config.Load(); err == nil {
+		applyGitSettings(cfg)
+	}
+
 	for {
 		// Repository Operations
 		fmt.Println(ui.FormatMenuHeader(ui.IconRepository, "Repository Operations"))
@@ -30,50 +35,70 @@ func StartMenu() {
 		fmt.Println(ui.FormatMenuItem(4, "Delete Branch"))
 		fmt.Println(ui.FormatMenuItem(5, "Switch Branch"))
 		fmt.Println(ui.FormatMenuItem(6, "List Branches"))
+		fmt.Println(ui.FormatMenuItem(7, "Clean Up Merged Branches"))
+		fmt.Println(ui.FormatMenuItem(8, "Sync Default Branch"))
 
 		// Changes and Staging
 		fmt.Println(ui.FormatMenuHeader(ui.IconCommit, "Changes and Staging"))
-		fmt.Println(ui.FormatMenuItem(7, "View Status"))
-		fmt.Println(ui.FormatMenuItem(8, "Add Files"))
-		fmt.Println(ui.FormatMenuItem(9, "Commit Changes"))
+		fmt.Println(ui.FormatMenuItem(9, "View Status"))
+		fmt.Println(ui.FormatMenuItem(10, "Add Files"))
+		fmt.Println(ui.FormatMenuItem(11, "Commit Changes"))
+		fmt.Println(ui.FormatMenuItem(12, "Amend Last Commit"))
+		fmt.Println(ui.FormatMenuItem(13, "Reword Commit"))
+		fmt.Println(ui.FormatMenuItem(14, "Undo Last Commit"))
+		fmt.Println(ui.FormatMenuItem(15, "Discard Changes"))
+		fmt.Println(ui.FormatMenuItem(16, "Clean Untracked Files"))
 
 		// Remote Operations
 		fmt.Println(ui.FormatMenuHeader(ui.IconRemote, "Remote Operations"))
-		fmt.Println(ui.FormatMenuItem(10, "Push Changes"))
-		fmt.Println(ui.FormatMenuItem(11, "Pull Changes"))
-		fmt.Println(ui.FormatMenuItem(12, "Fetch Updates"))
+		fmt.Println(ui.FormatMenuItem(17, "Push Changes"))
+		fmt.Println(ui.FormatMenuItem(18, "Pull Changes"))
+		fmt.Println(ui.FormatMenuItem(19, "Fetch Updates"))
+		fmt.Println(ui.FormatMenuItem(20, "Manage Remotes"))
 
 		// History and Diff
 		fmt.Println(ui.FormatMenuHeader(ui.IconHistory, "History and Diff"))
-		fmt.Println(ui.FormatMenuItem(13, "View Log"))
-		fmt.Println(ui.FormatMenuItem(14, "View Diff"))
-		fmt.Println(ui.FormatMenuItem(15, "Squash Commits"))
+		fmt.Println(ui.FormatMenuItem(21, "View Log"))
+		fmt.Println(ui.FormatMenuItem(22, "Verify Commit Signatures"))
+		fmt.Println(ui.FormatMenuItem(23, "Reflog Browser"))
+		fmt.Println(ui.FormatMenuItem(24, "View Diff"))
+		fmt.Println(ui.FormatMenuItem(25, "Squash Commits"))
+		fmt.Println(ui.FormatMenuItem(26, "Generate Changelog"))
 
 		// Stash Operations
 		fmt.Println(ui.FormatMenuHeader(ui.IconStash, "Stash Operations"))
-		fmt.Println(ui.FormatMenuItem(16, "Stash Save"))
-		fmt.Println(ui.FormatMenuItem(17, "Stash Pop"))
-		fmt.Println(ui.FormatMenuItem(18, "List Stashes"))
+		fmt.Println(ui.FormatMenuItem(27, "Stash Save"))
+		fmt.Println(ui.FormatMenuItem(28, "Stash Pop"))
+		fmt.Println(ui.FormatMenuItem(29, "Stash Browser"))
 
 		// Tag Operations
 		fmt.Println(ui.FormatMenuHeader(ui.IconTag, "Tag Operations"))
-		fmt.Println(ui.FormatMenuItem(19, "Create Tag"))
-		fmt.Println(ui.FormatMenuItem(20, "Delete Tag"))
-		fmt.Println(ui.FormatMenuItem(21, "List Tags"))
+		fmt.Println(ui.FormatMenuItem(30, "Create Tag"))
+		fmt.Println(ui.FormatMenuItem(31, "Delete Tag"))
+		fmt.Println(ui.FormatMenuItem(32, "List Tags"))
+		fmt.Println(ui.FormatMenuItem(33, "Push Tags"))
+		fmt.Println(ui.FormatMenuItem(34, "Compare Tags with Remote"))
+		fmt.Println(ui.FormatMenuItem(35, "Create Release"))
 
 		// GitHub Operations (New section)
 		fmt.Println(ui.FormatMenuHeader(ui.IconGitHub, "GitHub Operations"))
-		fmt.Println(ui.FormatMenuItem(22, "View Repository Info"))
-		fmt.Println(ui.FormatMenuItem(23, "Create Pull Request"))
-		fmt.Println(ui.FormatMenuItem(24, "List Issues"))
+		fmt.Println(ui.FormatMenuItem(36, "View Repository Info"))
+		fmt.Println(ui.FormatMenuItem(37, "Create Pull Request"))
+		fmt.Println(ui.FormatMenuItem(38, "List Pull Requests"))
+		fmt.Println(ui.FormatMenuItem(39, "View Pull Request"))
+		fmt.Println(ui.FormatMenuItem(40, "Merge Pull Request"))
+		fmt.Println(ui.FormatMenuItem(41, "Check Out Pull Request"))
+		fmt.Println(ui.FormatMenuItem(42, "Review Pull Request"))
+		fmt.Println(ui.FormatMenuItem(43, "List Issues"))
+		fmt.Println(ui.FormatMenuItem(44, "API Rate Limit Status"))
 
 		// Configuration and Exit
 		fmt.Println(ui.FormatMenuHeader(ui.IconConfig, "Configuration"))
-		fmt.Println(ui.FormatMenuItem(25, "Settings"))
+		fmt.Println(ui.FormatMenuItem(45, "Settings"))
 		fmt.Println(ui.FormatMenuHeader(ui.IconExit, "Exit"))
-		fmt.Println(ui.FormatMenuItem(26, "Exit"))
+		fmt.Println(ui.FormatMenuItem(46, "Exit"))
 
-		choice := GetInput(ui.FormatPrompt("Enter your choice (1-26): "))
+		choice := GetInput(ui.FormatPrompt("Enter your choice (1-46): "))
 
 		switch choice {
 		case "1":
@@ -89,44 +114,84 @@ func StartMenu() {
 		case "6":
 			handleListBranches()
 		case "7":
-			handleStatus()
+			handleCleanupBranches()
 		case "8":
-			handleAddFiles()
+			handleSyncDefaultBranch()
 		case "9":
-			handleCommit()
+			handleStatus()
 		case "10":
-			handlePush()
+			handleAddFiles()
 		case "11":
-			handlePull()
+			handleCommit()
 		case "12":
-			handleFetch()
+			handleAmendCommit()
 		case "13":
-			handleLog()
+			handleRewordCommit()
 		case "14":
-			handleDiff()
+			handleUndoCommit()
 		case "15":
-			handleSquash()
+			handleDiscard()
 		case "16":
-			handleStashSave()
+			handleClean()
 		case "17":
-			handleStashPop()
+			handlePush()
 		case "18":
-			handleStashList()
+			handlePull()
 		case "19":
-			handleCreateTag()
+			handleFetch()
 		case "20":
-			handleDeleteTag()
+			handleRemotes()
 		case "21":
-			handleListTags()
+			handleLog()
 		case "22":
-			handleRepoInfo()
+			handleVerifySignatures()
 		case "23":
-			handleCreatePR()
+			handleReflog()
 		case "24":
-			handleListIssues()
+			handleDiff()
 		case "25":
-			handleSettings()
+			handleSquash()
 		case "26":
+			handleChangelog()
+		case "27":
+			handleStashSave()
+		case "28":
+			handleStashPop()
+		case "29":
+			handleStashBrowser()
+		case "30":
+			handleCreateTag()
+		case "31":
+			handleDeleteTag()
+		case "32":
+			handleListTags()
+		case "33":
+			handlePushTags()
+		case "34":
+			handleCompareTags()
+		case "35":
+			handleRelease()
+		case "36":
+			handleRepoInfo()
+		case "37":
+			handleCreatePR()
+		case "38":
+			handleListPRs()
+		case "39":
+			handlePRDetails()
+		case "40":
+			handleMergePR()
+		case "41":
+			handleCheckoutPR()
+		case "42":
+			handleReviewPR()
+		case "43":
+			handleListIssues()
+		case "44":
+			handleRateLimit()
+		case "45":
+			handleSettings()
+		case "46":
 			fmt.Println(ui.FormatSuccess("Goodbye! Thank you for using GitHubber!"))
 			os.Exit(0)
 		default:
@@ -136,7 +201,8 @@ func StartMenu() {
 }
 
 func handleInit() {
-	if err := git.Init(); err != nil {
+	cfg := loadConfigOrDefault()
+	if err := git.InitWithBranch(cfg.Git.DefaultBranch); err != nil {
 		fmt.Println(ui.FormatError(fmt.Sprintf("Error initializing repository: %v", err)))
 		return
 	}
@@ -215,53 +281,6 @@ func handleAddFiles() {
 	fmt.Println("✅ Files added successfully!")
 }
 
-func handleCommit() {
-	message := GetInput("Enter commit message: ")
-	if err := git.Commit(message); err != nil {
-		fmt.Printf("❌ Error committing changes: %v\n", err)
-		return
-	}
-	fmt.Println("✅ Changes committed successfully!")
-}
-
-func handlePush() {
-	remote := GetInput("Enter remote name (default: origin): ")
-	if remote == "" {
-		remote = "origin"
-	}
-	branch := GetInput("Enter branch name: ")
-	if err := git.Push(remote, branch); err != nil {
-		fmt.Printf("❌ Error pushing changes: %v\n", err)
-		return
-	}
-	fmt.Println("✅ Changes pushed successfully!")
-}
-
-func handlePull() {
-	remote := GetInput("Enter remote name (default: origin): ")
-	if remote == "" {
-		remote = "origin"
-	}
-	branch := GetInput("Enter branch name: ")
-	if err := git.Pull(remote, branch); err != nil {
-		fmt.Printf("❌ Error pulling changes: %v\n", err)
-		return
-	}
-	fmt.Println("✅ Changes pulled successfully!")
-}
-
-func handleFetch() {
-	remote := GetInput("Enter remote name (default: origin): ")
-	if remote == "" {
-		remote = "origin"
-	}
-	if err := git.Fetch(remote); err != nil {
-		fmt.Printf("❌ Error fetching updates: %v\n", err)
-		return
-	}
-	fmt.Println("✅ Updates fetched successfully!")
-}
-
 func handleLog() {
 	n := 10 // Default to last 10 commits
 	logs, err := git.Log(n)
@@ -284,7 +303,15 @@ func handleDiff() {
 
 func handleStashSave() {
 	message := GetInput("Enter stash message: ")
-	if err := git.StashSave(message); err != nil {
+	paths := GetInput("Enter paths to stash (space-separated, or press enter for all): ")
+	includeUntracked := GetConfirmation("Include untracked files? (y/N): ")
+
+	opts := git.StashOptions{
+		Message:          message,
+		Paths:            strings.Fields(paths),
+		IncludeUntracked: includeUntracked,
+	}
+	if err := git.StashPush(opts); err != nil {
 		fmt.Printf("❌ Error stashing changes: %v\n", err)
 		return
 	}
@@ -299,19 +326,16 @@ func handleStashPop() {
 	fmt.Println("✅ Stash applied successfully!")
 }
 
-func handleStashList() {
-	list, err := git.StashList()
-	if err != nil {
-		fmt.Printf("❌ Error listing stashes: %v\n", err)
-		return
-	}
-	fmt.Printf("\n📦 Stash list:\n%s\n", list)
-}
-
 func handleCreateTag() {
 	name := GetInput("Enter tag name: ")
-	message := GetInput("Enter tag message: ")
-	if err := git.CreateTag(name, message); err != nil {
+	target := GetInput("Enter commit to tag (press enter for HEAD): ")
+	message := GetInput("Enter tag message (press enter for a lightweight tag): ")
+	opts := git.TagOptions{
+		Commit:    target,
+		Message:   message,
+		Annotated: message != "",
+	}
+	if err := git.CreateTagWithOptions(name, opts); err != nil {
 		fmt.Printf("❌ Error creating tag: %v\n", err)
 		return
 	}
@@ -325,6 +349,18 @@ func handleDeleteTag() {
 		return
 	}
 	fmt.Println("✅ Tag deleted successfully!")
+
+	if GetConfirmation("Also delete the tag from a remote? (y/N): ") {
+		remote, ok := selectRemote("Select remote")
+		if !ok {
+			return
+		}
+		if err := git.DeleteRemoteTag(remote, name); err != nil {
+			fmt.Printf("❌ Error deleting remote tag: %v\n", err)
+			return
+		}
+		fmt.Printf("✅ Tag deleted from %s!\n", remote)
+	}
 }
 
 func handleListTags() {
@@ -333,7 +369,14 @@ func handleListTags() {
 		fmt.Printf("❌ Error listing tags: %v\n", err)
 		return
 	}
-	fmt.Printf("\n🏷️  Tags:\n%s\n", tags)
+	if len(tags) == 0 {
+		fmt.Println(ui.FormatInfo("No tags found"))
+		return
+	}
+	fmt.Println("\n🏷️  Tags:")
+	for _, tag := range tags {
+		fmt.Println(formatTag(tag))
+	}
 }
 
 func handleSquash() {
@@ -376,8 +419,7 @@ func handleSquash() {
 	}
 
 	fmt.Println("✅ Commits squashed successfully!")
-	fmt.Println("⚠️  Note: If this branch was already pushed, you'll need to force push:")
-	fmt.Printf("git push -f origin %s\n", getCurrentBranch())
+	pushRewrittenBranch()
 }
 
 func getCurrentBranch() string {
@@ -390,75 +432,68 @@ func getCurrentBranch() string {
 
 // GitHub Operations (New handlers)
 func handleRepoInfo() {
-	client, err := github.NewClient()
+	// Get current repository info from git
+	repoInfo, err := git.GetRepositoryInfo()
 	if err != nil {
-		fmt.Println(ui.FormatError(fmt.Sprintf("Failed to create GitHub client: %v", err)))
-		fmt.Println(ui.FormatInfo("Please set GITHUB_TOKEN environment variable or configure authentication"))
+		fmt.Println(ui.FormatError("Not in a Git repository or no remote found"))
 		return
 	}
 
-	// Get current repository info from git
-	repoInfo, err := git.GetRepositoryInfo()
+	repo, err := parseGitHubRepository(repoInfo.URL)
 	if err != nil {
-		fmt.Println(ui.FormatError("Not in a Git repository or no remote origin found"))
+		fmt.Println(ui.FormatError(fmt.Sprintf("Failed to parse repository URL: %v", err)))
 		return
 	}
 
-	owner, repo, err := github.ParseRepoURL(repoInfo.URL)
+	client, err := github.NewClientForHost(repo.Host)
 	if err != nil {
-		fmt.Println(ui.FormatError(fmt.Sprintf("Failed to parse repository URL: %v", err)))
+		fmt.Println(ui.FormatError(fmt.Sprintf("Failed to create GitHub client: %v", err)))
+		fmt.Println(ui.FormatInfo("Please set GITHUB_TOKEN environment variable or configure authentication"))
 		return
 	}
 
-	repository, err := client.GetRepository(owner, repo)
+	repository, err := client.GetRepository(repo.Owner, repo.Name)
 	if err != nil {
-		fmt.Println(ui.FormatError(fmt.Sprintf("Failed to get repository information: %v", err)))
+		printGitHubError("Failed to get repository information", err)
 		return
 	}
 
 	fmt.Println(ui.FormatInfo("GitHub Repository Information"))
 	fmt.Println(ui.FormatBox(fmt.Sprintf(
-		"Name: %s\nOwner: %s\nDescription: %s\nURL: %s\nPrivate: %t\nLanguage: %s\nStars: %d\nForks: %d",
+		"Name: %s\nOwner: %s\nDescription: %s\nURL: %s\nPrivate: %t\nLanguage: %s\nStars: %d\nForks: %d\nDefault Branch: %s",
 		repository.Name, repository.Owner, repository.Description,
 		repository.URL, repository.Private, repository.Language,
-		repository.Stars, repository.Forks,
+		repository.Stars, repository.Forks, repository.DefaultBranch,
 	)))
 }
 
 func handleCreatePR() {
-	client, err := github.NewClient()
-	if err != nil {
-		fmt.Println(ui.FormatError(fmt.Sprintf("Failed to create GitHub client: %v", err)))
-		return
-	}
-
-	// Get current repository info
-	repoInfo, err := git.GetRepositoryInfo()
+	// Get current branch and the repository to open the pull request in
+	currentBranch := getCurrentBranch()
+	repo, head, err := pullRequestTarget(currentBranch)
 	if err != nil {
-		fmt.Println(ui.FormatError("Not in a Git repository"))
+		fmt.Println(ui.FormatError(fmt.Sprintf("Failed to determine repository: %v", err)))
 		return
 	}
 
-	owner, repo, err := github.ParseRepoURL(repoInfo.URL)
+	client, err := github.NewClientForHost(repo.Host)
 	if err != nil {
-		fmt.Println(ui.FormatError(fmt.Sprintf("Failed to parse repository URL: %v", err)))
+		fmt.Println(ui.FormatError(fmt.Sprintf("Failed to create GitHub client: %v", err)))
 		return
 	}
 
-	// Get current branch
-	currentBranch := getCurrentBranch()
-	
-	fmt.Println(ui.FormatInfo("Create Pull Request"))
+	fmt.Println(ui.FormatInfo(fmt.Sprintf("Create Pull Request in %s from %s", repo, head)))
 	title := GetInput(ui.FormatPrompt("Enter PR title: "))
 	body := GetInput(ui.FormatPrompt("Enter PR description: "))
-	base := GetInput(ui.FormatPrompt(fmt.Sprintf("Enter base branch (default: main): ")))
+	defaultBranch := resolveDefaultBranch(loadConfigOrDefault())
+	base := GetInput(ui.FormatPrompt(fmt.Sprintf("Enter base branch (default: %s): ", defaultBranch)))
 	if base == "" {
-		base = "main"
+		base = defaultBranch
 	}
 
-	pr, err := client.CreatePullRequest(owner, repo, title, body, currentBranch, base)
+	pr, err := client.CreatePullRequest(repo.Owner, repo.Name, title, body, head, base)
 	if err != nil {
-		fmt.Println(ui.FormatError(fmt.Sprintf("Failed to create pull request: %v", err)))
+		printGitHubError("Failed to create pull request", err)
 		return
 	}
 
@@ -467,46 +502,73 @@ func handleCreatePR() {
 	fmt.Println(ui.FormatInfo(fmt.Sprintf("URL: %s", pr.URL)))
 }
 
-func handleListIssues() {
-	client, err := github.NewClient()
+func handleListPRs() {
+	repo, err := baseGitHubRepository()
 	if err != nil {
-		fmt.Println(ui.FormatError(fmt.Sprintf("Failed to create GitHub client: %v", err)))
+		fmt.Println(ui.FormatError(fmt.Sprintf("Failed to determine repository: %v", err)))
 		return
 	}
 
-	// Get current repository info
-	repoInfo, err := git.GetRepositoryInfo()
+	client, err := github.NewClientForHost(repo.Host)
 	if err != nil {
-		fmt.Println(ui.FormatError("Not in a Git repository"))
+		fmt.Println(ui.FormatError(fmt.Sprintf("Failed to create GitHub client: %v", err)))
 		return
 	}
 
-	owner, repo, err := github.ParseRepoURL(repoInfo.URL)
+	state := GetInput(ui.FormatPrompt("Enter pull request state (open/closed/all, default: open): "))
+	if state == "" {
+		state = "open"
+	}
+
+	fmt.Println(ui.FormatInfo(fmt.Sprintf("Pull Requests (%s)", state)))
+	prs, err := showPaged(client.PullRequests(repo.Owner, repo.Name, state), loadConfigOrDefault().UI.PageSize, func(n int, pr *github.PullRequest) {
+		fmt.Printf("%s #%d: %s (%s) by %s\n",
+			ui.IconInfo, pr.Number, pr.Title, pr.State, pr.Author)
+	})
 	if err != nil {
-		fmt.Println(ui.FormatError(fmt.Sprintf("Failed to parse repository URL: %v", err)))
+		printGitHubError("Failed to list pull requests", err)
+		return
+	}
+	if len(prs) == 0 {
+		fmt.Println(ui.FormatInfo("No pull requests found"))
 		return
 	}
 
-	state := GetInput(ui.FormatPrompt("Enter issue state (open/closed/all, default: open): "))
-	if state == "" {
-		state = "open"
+	answer := strings.TrimPrefix(GetInput(ui.FormatPrompt("Enter a pull request number to view its details (or press Enter to skip): ")), "#")
+	if number, err := strconv.Atoi(answer); err == nil && number > 0 {
+		showPullRequest(client, repo, number)
 	}
+}
 
-	issues, err := client.ListIssues(owner, repo, state)
+func handleListIssues() {
+	repo, err := baseGitHubRepository()
 	if err != nil {
-		fmt.Println(ui.FormatError(fmt.Sprintf("Failed to list issues: %v", err)))
+		fmt.Println(ui.FormatError(fmt.Sprintf("Failed to determine repository: %v", err)))
 		return
 	}
 
-	if len(issues) == 0 {
-		fmt.Println(ui.FormatInfo("No issues found"))
+	client, err := github.NewClientForHost(repo.Host)
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Failed to create GitHub client: %v", err)))
 		return
 	}
 
+	state := GetInput(ui.FormatPrompt("Enter issue state (open/closed/all, default: open): "))
+	if state == "" {
+		state = "open"
+	}
+
 	fmt.Println(ui.FormatInfo(fmt.Sprintf("GitHub Issues (%s)", state)))
-	for _, issue := range issues {
+	issues, err := showPaged(client.Issues(repo.Owner, repo.Name, state), loadConfigOrDefault().UI.PageSize, func(n int, issue *github.Issue) {
 		fmt.Printf("%s #%d: %s (%s) by %s\n",
 			ui.IconInfo, issue.Number, issue.Title, issue.State, issue.Author)
+	})
+	if err != nil {
+		printGitHubError("Failed to list issues", err)
+		return
+	}
+	if len(issues) == 0 {
+		fmt.Println(ui.FormatInfo("No issues found"))
 	}
 }
 
@@ -522,9 +584,16 @@ func handleSettings() {
 	fmt.Println("2. Set GitHub token")
 	fmt.Println("3. Set default repository")
 	fmt.Println("4. UI preferences")
-	fmt.Println("5. Back to main menu")
+	fmt.Println("5. Commit signing")
+	fmt.Println("6. Auto-push after commits")
+	fmt.Println("7. Pull preferences")
+	fmt.Println("8. GitHub API cache")
+	fmt.Println("9. Diagnose GitHub credentials")
+	fmt.Println("10. Log in with browser")
+	fmt.Println("11. Log out")
+	fmt.Println("12. Back to main menu")
 
-	choice := GetInput(ui.FormatPrompt("Enter your choice (1-5): "))
+	choice := GetInput(ui.FormatPrompt("Enter your choice (1-12): "))
 
 	switch choice {
 	case "1":
@@ -536,6 +605,20 @@ func handleSettings() {
 	case "4":
 		setUIPreferences(cfg)
 	case "5":
+		setSigningPreferences(cfg)
+	case "6":
+		setAutoPush(cfg)
+	case "7":
+		setPullPreferences(cfg)
+	case "8":
+		setCachePreferences(cfg)
+	case "9":
+		diagnoseCredentials()
+	case "10":
+		handleLogin()
+	case "11":
+		handleLogout()
+	case "12":
 		return
 	default:
 		fmt.Println(ui.FormatError("Invalid choice"))
@@ -544,21 +627,25 @@ func handleSettings() {
 
 func showCurrentSettings(cfg *config.Config) {
 	fmt.Println(ui.FormatInfo("Current Settings"))
+	// Check every credential source, not just GITHUB_TOKEN and the
+	// configuration file, so tokens from login or gh count too
 	hasToken := "No"
-	if cfg.GetGitHubToken() != "" {
-		hasToken = "Yes"
+	if credential, err := github.ResolveCredential(defaultGitHubHost()); err == nil {
+		hasToken = fmt.Sprintf("%s for %s (from %s)", github.MaskToken(credential.Token), credential.Host, credential.Source)
 	}
 	
 	settings := fmt.Sprintf(
-		"GitHub Token: %s\nDefault Owner: %s\nDefault Repo: %s\nTheme: %s\nShow Emojis: %t\nPage Size: %d",
+		"GitHub Token: %s\nDefault Owner: %s\nDefault Repo: %s\nTheme: %s\nShow Emojis: %t\nPage Size: %d\nSign Commits: %t (%s)\nAuto Push: %t\nPull Mode: %s (autostash: %t)\nOffline Mode: %t",
 		hasToken, cfg.GitHub.DefaultOwner, cfg.GitHub.DefaultRepo,
 		cfg.UI.Theme, cfg.UI.ShowEmojis, cfg.UI.PageSize,
+		cfg.Git.SignCommits, cfg.Git.SigningFormat, cfg.Git.AutoPush,
+		cfg.Git.PullMode, cfg.Git.PullAutostash, cfg.GitHub.Offline,
 	)
 	fmt.Println(ui.FormatBox(settings))
 }
 
 func setGitHubToken(cfg *config.Config) {
-	token := GetInput(ui.FormatPrompt("Enter GitHub personal access token: "))
+	token := GetSecretInput(ui.FormatPrompt("Enter GitHub personal access token: "))
 	if token == "" {
 		fmt.Println(ui.FormatWarning("Token not set"))
 		return
@@ -0,0 +1,243 @@
+/*
+ * GitHubber - CLI Pull Request Merging
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Merging pull requests with pre-merge checks, branch deletion and local cleanup
+ */
+
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ritankarsaha/git-tool/internal/git"
+	"github.com/ritankarsaha/git-tool/internal/github"
+	"github.com/ritankarsaha/git-tool/internal/ui"
+)
+
+func handleMergePR() {
+	repo, client, ok := openGitHubRepository()
+	if !ok {
+		return
+	}
+	number, ok := selectPullRequest(client, repo)
+	if !ok {
+		return
+	}
+
+	pr, err := client.GetPullRequestDetail(repo.Owner, repo.Name, number)
+	if err != nil {
+		printGitHubError("Failed to get pull request", err)
+		return
+	}
+	fmt.Println(ui.FormatInfo(fmt.Sprintf("#%d %s (%s -> %s)", pr.Number, pr.Title, pr.Head, pr.Base)))
+
+	check, err := client.CheckMerge(repo.Owner, repo.Name, pr)
+	if err != nil {
+		printGitHubError("Failed to check pull request", err)
+		return
+	}
+	if !confirmMergeCheck(pr, check) {
+		return
+	}
+
+	// Fall back to all methods if the repository settings are unavailable
+	methods := github.MergeMethods
+	deleteOnMerge := false
+	defaultBranch := ""
+	if repository, err := client.GetRepository(repo.Owner, repo.Name); err == nil {
+		methods = repository.MergeMethods
+		deleteOnMerge = repository.DeleteBranchOnMerge
+		defaultBranch = repository.DefaultBranch
+	}
+
+	method, ok := selectMergeMethod(methods)
+	if !ok {
+		return
+	}
+	opts := github.MergeOptions{Method: method, SHA: pr.HeadSHA}
+	if method == github.MergeMethodSquash {
+		opts.CommitTitle, opts.CommitMessage = squashCommitMessage(pr)
+	}
+
+	if !GetConfirmation(ui.FormatPrompt(fmt.Sprintf("Merge #%d into %s using %s? (y/N): ", pr.Number, pr.Base, method))) {
+		fmt.Println(ui.FormatInfo("Merge cancelled"))
+		return
+	}
+	result, err := client.MergePullRequest(repo.Owner, repo.Name, pr.Number, opts)
+	if err != nil {
+		printGitHubError("Failed to merge pull request", err)
+		return
+	}
+	fmt.Println(ui.FormatSuccess(fmt.Sprintf("Merged #%d into %s (%s)", pr.Number, pr.Base, shortHash(result.SHA))))
+
+	deleteHeadBranch(client, repo, pr, deleteOnMerge, defaultBranch)
+	cleanUpLocalBranch(pr)
+}
+
+// confirmMergeCheck shows what stands in the way of the merge and asks
+// whether to go ahead anyway
+func confirmMergeCheck(pr *github.PullRequestDetail, check *github.MergeCheck) bool {
+	if pr.Merged || pr.State != "open" {
+		fmt.Println(ui.FormatError(check.Blockers[0]))
+		return false
+	}
+
+	for _, blocker := range check.Blockers {
+		fmt.Printf("  %s %s\n", ui.IconError, blocker)
+	}
+	for _, warning := range check.Warnings {
+		fmt.Printf("  %s %s\n", ui.IconWarning, warning)
+	}
+
+	switch {
+	case !check.Ready():
+		fmt.Println(ui.FormatWarning("GitHub will refuse this merge unless you can bypass branch protection"))
+		return GetConfirmation(ui.FormatPrompt("Try to merge anyway? (y/N): "))
+	case len(check.Warnings) > 0:
+		return GetConfirmation(ui.FormatPrompt("Continue with the merge? (y/N): "))
+	}
+	fmt.Println(ui.FormatSuccess("Ready to merge"))
+	return true
+}
+
+// selectMergeMethod asks for one of the allowed merge methods, defaulting to
+// the first
+func selectMergeMethod(methods []string) (string, bool) {
+	if len(methods) == 1 {
+		fmt.Println(ui.FormatInfo(fmt.Sprintf("This repository only allows %s merges", methods[0])))
+		return methods[0], true
+	}
+
+	answer := strings.ToLower(GetInput(ui.FormatPrompt(fmt.Sprintf("Merge method (%s, default: %s): ", strings.Join(methods, "/"), methods[0]))))
+	if answer == "" {
+		return methods[0], true
+	}
+	for _, method := range methods {
+		if answer == method {
+			return method, true
+		}
+	}
+	fmt.Println(ui.FormatError(fmt.Sprintf("Merge method must be one of %s", strings.Join(methods, ", "))))
+	return "", false
+}
+
+// squashCommitMessage asks for the title and message of the squash commit.
+// Empty values leave the choice to GitHub's repository settings.
+func squashCommitMessage(pr *github.PullRequestDetail) (title, message string) {
+	defaultTitle := fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
+	title = GetInput(ui.FormatPrompt(fmt.Sprintf("Commit title (default: %s): ", defaultTitle)))
+	if title == "" {
+		title = defaultTitle
+	}
+
+	if len(pr.Commits) > 0 {
+		fmt.Println(ui.FormatInfo("Commits being squashed:"))
+		for _, commit := range pr.Commits {
+			fmt.Printf("   %s %s\n", shortHash(commit.SHA), commit.Message)
+		}
+	}
+	if GetConfirmation(ui.FormatPrompt("Write a custom commit message? (y/N): ")) {
+		message = GetMultilineInput(ui.FormatPrompt("Enter the commit message (finish with a line containing only '.'):"))
+	}
+	return title, message
+}
+
+// deleteHeadBranch offers to delete the merged pull request's branch on
+// GitHub, unless GitHub does so itself
+func deleteHeadBranch(client *github.Client, repo *github.RepoRef, pr *github.PullRequestDetail, deleteOnMerge bool, defaultBranch string) {
+	owner, name, ok := strings.Cut(pr.HeadRepo, "/")
+	if !ok {
+		// The fork the pull request came from has been deleted
+		return
+	}
+	sameRepo := strings.EqualFold(pr.HeadRepo, repo.Owner+"/"+repo.Name)
+	if sameRepo && (pr.Head == defaultBranch || pr.Head == pr.Base) {
+		return
+	}
+	if sameRepo && deleteOnMerge {
+		fmt.Println(ui.FormatInfo(fmt.Sprintf("GitHub deletes %s automatically after merging", pr.Head)))
+		return
+	}
+
+	if !GetConfirmation(ui.FormatPrompt(fmt.Sprintf("Delete branch %s from %s? (y/N): ", pr.Head, pr.HeadRepo))) {
+		return
+	}
+	if err := client.DeleteBranch(owner, name, pr.Head); err != nil {
+		printGitHubError("Failed to delete branch", err)
+		return
+	}
+	fmt.Println(ui.FormatSuccess(fmt.Sprintf("Deleted branch %s from %s", pr.Head, pr.HeadRepo)))
+}
+
+// localPullRequestBranch returns the local branch a pull request was
+// opened from: a branch with the head's name that points at the head
+// commit or tracks the head repository. It returns "" if there is none.
+func localPullRequestBranch(pr *github.PullRequestDetail) string {
+	if pr.Head == pr.Base {
+		return ""
+	}
+	commit, err := git.BranchCommit(pr.Head)
+	if err != nil {
+		return ""
+	}
+	if commit == pr.HeadSHA {
+		return pr.Head
+	}
+	if remote, remoteBranch, ok := git.Upstream(pr.Head); ok && remoteBranch == pr.Head {
+		if tracked, err := githubRepository(remote); err == nil && strings.EqualFold(tracked.Owner+"/"+tracked.Name, pr.HeadRepo) {
+			return pr.Head
+		}
+	}
+	return ""
+}
+
+// cleanUpLocalBranch switches from the merged pull request's local branch
+// to the base branch, pulls the merge and deletes the local branch
+func cleanUpLocalBranch(pr *github.PullRequestDetail) {
+	branch := localPullRequestBranch(pr)
+	if branch == "" {
+		return
+	}
+	if !GetConfirmation(ui.FormatPrompt(fmt.Sprintf("Switch to %s, pull and delete the local branch %s? (y/N): ", pr.Base, branch))) {
+		return
+	}
+
+	if current, _ := git.CurrentBranch(); current == branch {
+		if clean, err := git.IsWorkingDirectoryClean(); err == nil && !clean {
+			fmt.Println(ui.FormatWarning(fmt.Sprintf("You have local changes on %s; commit or stash them, then switch branches manually", branch)))
+			return
+		}
+		if err := git.SwitchBranch(pr.Base); err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("Error switching to %s: %v", pr.Base, err)))
+			return
+		}
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("Switched to %s", pr.Base)))
+	}
+
+	remote, err := git.BaseRemote()
+	if err != nil {
+		fmt.Println(ui.FormatWarning(fmt.Sprintf("Cannot pull: %v", err)))
+	} else if current, _ := git.CurrentBranch(); current == pr.Base {
+		summary, err := git.PullWithOptions(git.PullOptions{
+			Remote: remote,
+			Branch: pr.Base,
+			Mode:   pullMode(loadConfigOrDefault()),
+		})
+		if err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("Error pulling changes: %v", err)))
+		} else {
+			printPullSummary(summary)
+		}
+	} else if err := git.FastForwardBranch(remote, pr.Base); err != nil {
+		fmt.Println(ui.FormatWarning(fmt.Sprintf("%v", err)))
+	} else {
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("%s is up to date with %s/%s", pr.Base, remote, pr.Base)))
+	}
+
+	if err := git.DeleteLandedBranch(branch, pr.HeadSHA); err != nil {
+		fmt.Println(ui.FormatWarning(fmt.Sprintf("%v", err)))
+		return
+	}
+	fmt.Println(ui.FormatSuccess(fmt.Sprintf("Deleted local branch %s", branch)))
+}
@@ -0,0 +1,55 @@
+/*
+ * GitHubber - CLI Paging
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Paged display of paginated GitHub results
+ */
+
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ritankarsaha/git-tool/internal/git"
+	"github.com/ritankarsaha/git-tool/internal/github"
+	"github.com/ritankarsaha/git-tool/internal/ui"
+)
+
+// showPaged renders items from p pageSize at a time, only fetching more
+// once the user asks for the next page. render receives the 1-based
+// position of each item. It returns every item shown.
+func showPaged[T any](p *github.Paginator[T], pageSize int, render func(n int, item T)) ([]T, error) {
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+
+	var shown []T
+	for {
+		items, err := p.Take(pageSize)
+		for _, item := range items {
+			shown = append(shown, item)
+			render(len(shown), item)
+		}
+		if err != nil {
+			return shown, err
+		}
+		if len(items) < pageSize || p.Done() {
+			return shown, nil
+		}
+
+		answer := GetInput(ui.FormatPrompt(fmt.Sprintf("Showing %d so far. Press enter for more or 'q' to stop: ", len(shown))))
+		if strings.EqualFold(answer, "q") {
+			return shown, nil
+		}
+	}
+}
+
+// baseGitHubRepository returns the GitHub repository issues and pull
+// requests live in (upstream in fork setups)
+func baseGitHubRepository() (*github.RepoRef, error) {
+	remote, err := git.BaseRemote()
+	if err != nil {
+		return nil, fmt.Errorf("not in a Git repository or no remote found")
+	}
+	return githubRepository(remote)
+}
@@ -0,0 +1,121 @@
+/*
+ * GitHubber - CLI Pull
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Pulling with a per-repository pull mode, autostash and an incoming change summary
+ */
+
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ritankarsaha/git-tool/internal/config"
+	"github.com/ritankarsaha/git-tool/internal/git"
+	"github.com/ritankarsaha/git-tool/internal/ui"
+)
+
+// pullMode returns the pull mode for the current repository, falling back
+// to the configured default
+func pullMode(cfg *config.Config) git.PullMode {
+	if mode, ok := git.RepoPullMode(); ok {
+		return mode
+	}
+	if mode, err := git.ParsePullMode(cfg.Git.PullMode); err == nil {
+		return mode
+	}
+	return git.PullModeMerge
+}
+
+func handlePull() {
+	cfg := loadConfigOrDefault()
+
+	current, err := git.CurrentBranch()
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Cannot pull: %v", err)))
+		return
+	}
+	opts := git.PullOptions{Branch: current}
+	if remote, remoteBranch, ok := git.Upstream(current); ok {
+		opts.Remote, opts.Branch = remote, remoteBranch
+		fmt.Println(ui.FormatInfo(fmt.Sprintf("Pulling %s/%s into %s", remote, remoteBranch, current)))
+	} else {
+		remote, ok := selectRemote("Select remote")
+		if !ok {
+			return
+		}
+		opts.Remote = remote
+		if branch := GetInput(ui.FormatPrompt(fmt.Sprintf("Enter branch name (default: %s): ", current))); branch != "" {
+			opts.Branch = branch
+		}
+	}
+
+	opts.Mode = pullMode(cfg)
+	input := GetInput(ui.FormatPrompt(fmt.Sprintf("Pull mode (merge/rebase/ff-only, default: %s): ", opts.Mode)))
+	if input != "" {
+		mode, err := git.ParsePullMode(input)
+		if err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("%v", err)))
+			return
+		}
+		opts.Mode = mode
+		if GetConfirmation(ui.FormatPrompt(fmt.Sprintf("Always use %s for this repository? (y/N): ", mode))) {
+			if err := git.SetRepoPullMode(mode); err != nil {
+				fmt.Println(ui.FormatWarning(fmt.Sprintf("%v", err)))
+			}
+		}
+	}
+
+	opts.Autostash = cfg.Git.PullAutostash
+	if clean, err := git.IsWorkingDirectoryClean(); err == nil && !clean && !opts.Autostash {
+		opts.Autostash = GetConfirmation(ui.FormatPrompt("You have local changes. Stash them during the pull? (y/N): "))
+	}
+
+	summary, err := git.PullWithOptions(opts)
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Error pulling changes: %v", err)))
+		fmt.Println(ui.FormatInfo("The branch was left unchanged"))
+		return
+	}
+	printPullSummary(summary)
+}
+
+// printPullSummary lists the incoming commits and changed files of a pull
+func printPullSummary(summary *git.PullSummary) {
+	if summary.UpToDate() {
+		fmt.Println(ui.FormatSuccess("Already up to date"))
+		return
+	}
+
+	fmt.Println(ui.FormatSuccess(fmt.Sprintf("Pulled %d commits changing %d files", len(summary.Commits), len(summary.Files))))
+	for _, commit := range summary.Commits {
+		fmt.Printf("   %s %s (%s)\n", commit.ShortHash(), commit.Subject, commit.Author)
+	}
+	if len(summary.Files) > 0 {
+		fmt.Println()
+	}
+	for _, file := range summary.Files {
+		if file.Additions < 0 {
+			fmt.Printf("   %s %s (binary)\n", file.Status, file.Path)
+			continue
+		}
+		fmt.Printf("   %s %s (+%d -%d)\n", file.Status, file.Path, file.Additions, file.Deletions)
+	}
+}
+
+func setPullPreferences(cfg *config.Config) {
+	mode := GetInput(ui.FormatPrompt(fmt.Sprintf("Default pull mode (merge/rebase/ff-only, default: %s): ", cfg.Git.PullMode)))
+	if mode != "" {
+		cfg.Git.PullMode = mode
+	}
+	cfg.Git.PullAutostash = GetConfirmation(ui.FormatPrompt("Always stash local changes during pulls? (y/N): "))
+
+	if err := cfg.Validate(); err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Invalid configuration: %v", err)))
+		return
+	}
+	if err := cfg.Save(); err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Failed to save configuration: %v", err)))
+		return
+	}
+	fmt.Println(ui.FormatSuccess("Pull preferences saved successfully"))
+}
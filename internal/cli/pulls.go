@@ -0,0 +1,287 @@
+/*
+ * GitHubber - CLI Pull Requests
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Pull request selection and detail view
+ */
+
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ritankarsaha/git-tool/internal/github"
+	"github.com/ritankarsaha/git-tool/internal/ui"
+)
+
+// openGitHubRepository returns the base repository of the current
+// directory and a client for its host, printing any error
+func openGitHubRepository() (*github.RepoRef, *github.Client, bool) {
+	repo, err := baseGitHubRepository()
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Failed to determine repository: %v", err)))
+		return nil, nil, false
+	}
+
+	client, err := github.NewClientForHost(repo.Host)
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Failed to create GitHub client: %v", err)))
+		return nil, nil, false
+	}
+	return repo, client, true
+}
+
+// currentBranchPullRequest returns the open pull request of the checked out
+// branch, or nil if there is none
+func currentBranchPullRequest(client *github.Client, repo *github.RepoRef) *github.PullRequest {
+	target, head, err := pullRequestTarget(getCurrentBranch())
+	if err != nil || target.String() != repo.String() {
+		return nil
+	}
+	if !strings.Contains(head, ":") {
+		head = repo.Owner + ":" + head
+	}
+	pr, err := client.FindPullRequestForBranch(repo.Owner, repo.Name, head)
+	if err != nil {
+		return nil
+	}
+	return pr
+}
+
+// selectPullRequest asks for a pull request number, offering the current
+// branch's pull request as the default
+func selectPullRequest(client *github.Client, repo *github.RepoRef) (int, bool) {
+	prompt := "Pull request number: "
+	defaultNumber := 0
+	if pr := currentBranchPullRequest(client, repo); pr != nil {
+		defaultNumber = pr.Number
+		prompt = fmt.Sprintf("Pull request number (default: #%d %s): ", pr.Number, pr.Title)
+	}
+
+	answer := strings.TrimPrefix(GetInput(ui.FormatPrompt(prompt)), "#")
+	if answer == "" && defaultNumber != 0 {
+		return defaultNumber, true
+	}
+	number, err := strconv.Atoi(answer)
+	if err != nil || number <= 0 {
+		fmt.Println(ui.FormatError("Invalid pull request number"))
+		return 0, false
+	}
+	return number, true
+}
+
+func handlePRDetails() {
+	repo, client, ok := openGitHubRepository()
+	if !ok {
+		return
+	}
+	number, ok := selectPullRequest(client, repo)
+	if !ok {
+		return
+	}
+	showPullRequest(client, repo, number)
+}
+
+// showPullRequest fetches and prints the details of a pull request
+func showPullRequest(client *github.Client, repo *github.RepoRef, number int) {
+	fmt.Println(ui.FormatInfo(fmt.Sprintf("Loading pull request #%d...", number)))
+	detail, err := client.GetPullRequestDetail(repo.Owner, repo.Name, number)
+	if err != nil {
+		printGitHubError("Failed to get pull request", err)
+		return
+	}
+	printPullRequestDetail(repo, detail)
+}
+
+func printPullRequestDetail(repo *github.RepoRef, pr *github.PullRequestDetail) {
+	fmt.Println(ui.FormatTitle(fmt.Sprintf("#%d %s", pr.Number, pr.Title)))
+
+	head := pr.Head
+	if owner, _, _ := strings.Cut(pr.HeadRepo, "/"); pr.HeadRepo != "" && pr.HeadRepo != repo.Owner+"/"+repo.Name {
+		head = owner + ":" + pr.Head
+	}
+	labels := "none"
+	if len(pr.Labels) > 0 {
+		labels = strings.Join(pr.Labels, ", ")
+	}
+	fmt.Println(ui.FormatBox(fmt.Sprintf(
+		"State: %s\nAuthor: %s\nBranches: %s -> %s\nMergeable: %s\nLabels: %s\nChanges: %d commits, %d files, %s\nCreated: %s\nUpdated: %s\nURL: %s",
+		pullRequestState(pr.PullRequest), pr.Author, head, pr.Base, mergeability(pr.PullRequest), labels,
+		pr.PullRequest.Commits, pr.ChangedFiles, ui.FormatDiffstat(pr.Additions, pr.Deletions, 20),
+		pr.CreatedAt.Local().Format("2006-01-02 15:04"), pr.UpdatedAt.Local().Format("2006-01-02 15:04"), pr.URL,
+	)))
+
+	fmt.Println(ui.FormatInfo("Description"))
+	if body := strings.TrimSpace(pr.Body); body != "" {
+		fmt.Println(ui.FormatBox(body))
+	} else {
+		fmt.Println("  (no description)")
+	}
+
+	if len(pr.LinkedIssues) > 0 {
+		fmt.Println(ui.FormatInfo(fmt.Sprintf("Closes %s", strings.Join(pr.LinkedIssues, ", "))))
+	}
+
+	fmt.Println(ui.FormatInfo("Reviews"))
+	if len(pr.Reviews) == 0 && len(pr.RequestedReviewers) == 0 {
+		fmt.Println("  No reviews yet")
+	}
+	for _, review := range pr.Reviews {
+		fmt.Printf("  %s %s: %s\n", reviewIcon(review.State), review.Reviewer, strings.ToLower(strings.ReplaceAll(review.State, "_", " ")))
+	}
+	for _, reviewer := range pr.RequestedReviewers {
+		fmt.Printf("  ⏳ %s: review requested\n", reviewer)
+	}
+
+	fmt.Println(ui.FormatInfo(fmt.Sprintf("Checks (%s)", checkSummary(pr.Checks))))
+	for _, check := range pr.Checks {
+		line := fmt.Sprintf("  %s %s: %s", checkIcon(check.State), check.Name, check.State)
+		if check.Description != "" {
+			line += " - " + check.Description
+		}
+		fmt.Println(line)
+	}
+
+	fmt.Println(ui.FormatInfo(fmt.Sprintf("Commits (%d)", len(pr.Commits))))
+	for _, commit := range pr.Commits {
+		fmt.Printf("  %s %s (%s)\n", shortHash(commit.SHA), commit.Message, commit.Author)
+	}
+
+	fmt.Println(ui.FormatInfo(fmt.Sprintf("Files (%d)", len(pr.Files))))
+	for _, file := range pr.Files {
+		name := file.Filename
+		if file.PreviousFilename != "" {
+			name = file.PreviousFilename + " -> " + file.Filename
+		}
+		fmt.Printf("  %-8s %s %s\n", file.Status, name, ui.FormatDiffstat(file.Additions, file.Deletions, 20))
+	}
+
+	fmt.Println(ui.FormatInfo("Timeline"))
+	for _, event := range pr.Timeline {
+		if line := timelineLine(event); line != "" {
+			fmt.Println("  " + line)
+		}
+	}
+}
+
+// pullRequestState describes whether a pull request is open, a draft,
+// merged or closed
+func pullRequestState(pr *github.PullRequest) string {
+	switch {
+	case pr.Merged:
+		return "merged"
+	case pr.State == "open" && pr.Draft:
+		return "draft"
+	}
+	return pr.State
+}
+
+// mergeability describes whether GitHub can merge a pull request
+func mergeability(pr *github.PullRequest) string {
+	switch {
+	case pr.Merged || pr.State != "open":
+		return "n/a"
+	case pr.Mergeable == nil:
+		return "unknown (GitHub is still checking)"
+	case !*pr.Mergeable:
+		return "no, there are merge conflicts"
+	}
+
+	switch pr.MergeableState {
+	case "clean", "has_hooks":
+		return "yes"
+	case "unstable":
+		return "yes, but some checks are failing"
+	case "blocked":
+		return "blocked by branch protection (reviews or required checks)"
+	case "behind":
+		return "yes, but the branch is behind its base"
+	case "draft":
+		return "no, the pull request is a draft"
+	}
+	return "yes"
+}
+
+func reviewIcon(state string) string {
+	switch state {
+	case "APPROVED":
+		return ui.IconSuccess
+	case "CHANGES_REQUESTED":
+		return ui.IconError
+	case "DISMISSED":
+		return "🚫"
+	}
+	return "💬"
+}
+
+func checkIcon(state string) string {
+	switch github.CombinedCheckState([]*github.CheckResult{{State: state}}) {
+	case "success":
+		if state == "neutral" || state == "skipped" {
+			return "⏭️"
+		}
+		return ui.IconSuccess
+	case "failure":
+		return ui.IconError
+	}
+	return "⏳"
+}
+
+// checkSummary counts checks by outcome
+func checkSummary(checks []*github.CheckResult) string {
+	if len(checks) == 0 {
+		return "none reported"
+	}
+	counts := make(map[string]int)
+	for _, check := range checks {
+		counts[github.CombinedCheckState([]*github.CheckResult{check})]++
+	}
+	var parts []string
+	for _, state := range []string{"success", "pending", "failure"} {
+		if counts[state] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[state], map[string]string{
+				"success": "passed", "pending": "pending", "failure": "failed",
+			}[state]))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// timelineLine renders a timeline event as a single line, or "" for events
+// not worth showing
+func timelineLine(event *github.TimelineEvent) string {
+	when := event.Time.Local().Format("2006-01-02 15:04")
+	summary := firstLine(event.Body, 80)
+
+	switch event.Event {
+	case "commented":
+		return fmt.Sprintf("%s %s commented: %s", when, event.Actor, summary)
+	case "reviewed":
+		text := fmt.Sprintf("%s %s reviewed (%s)", when, event.Actor, strings.ToLower(strings.ReplaceAll(event.Detail, "_", " ")))
+		if summary != "" {
+			text += ": " + summary
+		}
+		return text
+	case "committed":
+		return fmt.Sprintf("%s %s committed %s %s", when, event.Actor, event.Detail, summary)
+	case "subscribed", "unsubscribed", "mentioned", "referenced", "head_ref_deleted", "head_ref_restored":
+		return ""
+	}
+
+	text := fmt.Sprintf("%s %s %s", when, event.Actor, strings.ReplaceAll(event.Event, "_", " "))
+	if event.Detail != "" {
+		text += " " + event.Detail
+	}
+	return text
+}
+
+// firstLine returns the first line of text, shortened to at most width runes
+func firstLine(text string, width int) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	line = strings.TrimSpace(line)
+	if runes := []rune(line); len(runes) > width {
+		return string(runes[:width-3]) + "..."
+	}
+	return line
+}
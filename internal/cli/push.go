@@ -0,0 +1,194 @@
+/*
+ * GitHubber - CLI Push Support
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Upstream-aware pushing, protection checks and guided recovery from rejected pushes
+ */
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ritankarsaha/git-tool/internal/config"
+	"github.com/ritankarsaha/git-tool/internal/git"
+	"github.com/ritankarsaha/git-tool/internal/github"
+	"github.com/ritankarsaha/git-tool/internal/ui"
+)
+
+// autoPush pushes the current branch after a commit when AutoPush is enabled
+func autoPush(cfg *config.Config) {
+	if !cfg.Git.AutoPush {
+		return
+	}
+	pushCurrentBranch()
+}
+
+// pushCurrentBranch pushes the current branch to its upstream, setting the
+// upstream on first push. Like every push it goes through pushWithChecks, so
+// branches GitHub reports as protected are confirmed first.
+func pushCurrentBranch() {
+	opts, err := git.CurrentPushOptions()
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Cannot push: %v", err)))
+		return
+	}
+	pushWithChecks(opts)
+}
+
+func reportPush(result *git.PushResult) {
+	switch {
+	case result.UpToDate:
+		fmt.Println(ui.FormatInfo(fmt.Sprintf("%s/%s is already up to date", result.Remote, result.RemoteRef)))
+	case result.SetUpstream:
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("Pushed %s to %s/%s and set it as upstream", result.Branch, result.Remote, result.RemoteRef)))
+	default:
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("Pushed %s to %s/%s", result.Branch, result.Remote, result.RemoteRef)))
+	}
+}
+
+// recoverRejectedPush explains why the push of the local branch was
+// rejected and walks the user through the usual way out. Recovery rebases or
+// moves commits of the checked out branch, so it is only offered when that
+// is the branch that was pushed.
+func recoverRejectedPush(branch string, rejected *git.PushRejectedError) {
+	fmt.Println(ui.FormatWarning(rejected.Error()))
+
+	if rejected.Reason == git.RejectionStaleLease {
+		fmt.Println(ui.FormatInfo(fmt.Sprintf("Nothing was overwritten. Fetch %s and check the new commits on %s before force pushing again.", rejected.Remote, rejected.Branch)))
+		return
+	}
+	if current, _ := git.CurrentBranch(); current != branch && rejected.Reason != git.RejectionOther {
+		fmt.Println(ui.FormatInfo(fmt.Sprintf("Commits kept locally; switch to %s to bring it up to date, then push again", branch)))
+		return
+	}
+
+	switch rejected.Reason {
+	case git.RejectionNonFastForward:
+		fmt.Println(ui.FormatInfo("Your commit is saved locally. Rebase it onto the remote changes to push it."))
+		if !GetConfirmation(ui.FormatPrompt(fmt.Sprintf("Pull %s/%s with rebase and push again? (y/N): ", rejected.Remote, rejected.Branch))) {
+			fmt.Println(ui.FormatInfo("Commit kept locally; push it when you are ready"))
+			return
+		}
+		if err := git.PullRebase(rejected.Remote, rejected.Branch); err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("%v", err)))
+			fmt.Println(ui.FormatInfo("Resolve the conflicts manually, then push again"))
+			return
+		}
+		pushCurrentBranch()
+
+	case git.RejectionProtectedBranch:
+		fmt.Println(ui.FormatInfo("Protected branches only accept changes through pull requests."))
+		newBranch := GetInput(ui.FormatPrompt("Move your commits to a new branch (enter a name, or press enter to skip): "))
+		if newBranch == "" {
+			fmt.Println(ui.FormatInfo("Commit kept locally; push it when you are ready"))
+			return
+		}
+		if err := git.MoveCommitsToNewBranch(newBranch); err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("Failed to move commits: %v", err)))
+			return
+		}
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("Switched to %s", newBranch)))
+		pushCurrentBranch()
+		fmt.Println(ui.FormatInfo("Use \"Create Pull Request\" to open a pull request for the new branch"))
+
+	default:
+		fmt.Println(ui.FormatInfo("Commit kept locally; check the remote's response above before pushing again"))
+	}
+}
+
+func setAutoPush(cfg *config.Config) {
+	cfg.Git.AutoPush = GetConfirmation(ui.FormatPrompt("Push automatically after each commit? (y/N): "))
+	if err := cfg.Save(); err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Failed to save configuration: %v", err)))
+		return
+	}
+	fmt.Println(ui.FormatSuccess("Auto-push preference saved successfully"))
+}
+
+func handlePush() {
+	current, err := git.CurrentBranch()
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Cannot push: %v", err)))
+		return
+	}
+	branch := GetInput(ui.FormatPrompt(fmt.Sprintf("Enter branch name (default: %s): ", current)))
+	if branch == "" {
+		branch = current
+	}
+
+	opts, err := git.BranchPushOptions(branch)
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Cannot push: %v", err)))
+		return
+	}
+	if opts.SetUpstream {
+		remote, ok := selectRemote(fmt.Sprintf("%s has no upstream yet; select remote to publish to", branch))
+		if !ok {
+			return
+		}
+		opts.Remote = remote
+	}
+	opts.ForceWithLease = GetConfirmation(ui.FormatPrompt("Force push with lease (only needed after rewriting history)? (y/N): "))
+
+	pushWithChecks(opts)
+}
+
+// pushRewrittenBranch offers to publish the current branch after its
+// history was rewritten, using --force-with-lease instead of a bare force
+func pushRewrittenBranch() {
+	opts, err := git.CurrentPushOptions()
+	if err != nil || opts.SetUpstream {
+		return
+	}
+	prompt := fmt.Sprintf("Update %s/%s with --force-with-lease? (y/N): ", opts.Remote, opts.RemoteRef)
+	if !GetConfirmation(ui.FormatPrompt(prompt)) {
+		fmt.Println(ui.FormatInfo("The rewritten history is local only; push it with force-with-lease when ready"))
+		return
+	}
+	opts.ForceWithLease = true
+	pushWithChecks(opts)
+}
+
+// pushWithChecks pushes according to opts after checking GitHub branch
+// protection. Force pushes to protected branches are refused.
+func pushWithChecks(opts git.PushOptions) {
+	if protectedOnGitHub(opts.Remote, opts.RemoteRef) {
+		if opts.ForceWithLease {
+			fmt.Println(ui.FormatError(fmt.Sprintf("%s/%s is protected on GitHub; refusing to force push", opts.Remote, opts.RemoteRef)))
+			return
+		}
+		fmt.Println(ui.FormatWarning(fmt.Sprintf("%s/%s is protected on GitHub and may only accept changes through pull requests", opts.Remote, opts.RemoteRef)))
+		if !GetConfirmation(ui.FormatPrompt("Push anyway? (y/N): ")) {
+			fmt.Println(ui.FormatInfo("Push cancelled"))
+			return
+		}
+	}
+
+	result, err := git.PushBranch(opts)
+	if err != nil {
+		var rejected *git.PushRejectedError
+		if errors.As(err, &rejected) {
+			recoverRejectedPush(opts.Branch, rejected)
+			return
+		}
+		fmt.Println(ui.FormatError(fmt.Sprintf("Error pushing changes: %v", err)))
+		return
+	}
+	reportPush(result)
+}
+
+// protectedOnGitHub reports whether the GitHub API lists branch on remote
+// as protected. Remotes outside GitHub and lookup failures report false.
+func protectedOnGitHub(remote, branch string) bool {
+	repo, err := githubRepository(remote)
+	if err != nil {
+		return false
+	}
+	client, err := github.NewClientForHost(repo.Host)
+	if err != nil {
+		return false
+	}
+	protected, err := client.IsBranchProtected(repo.Owner, repo.Name, branch)
+	return err == nil && protected
+}
@@ -0,0 +1,102 @@
+/*
+ * GitHubber - CLI Rate Limits
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: API quota status and hints for GitHub API errors
+ */
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ritankarsaha/git-tool/internal/github"
+	"github.com/ritankarsaha/git-tool/internal/ui"
+)
+
+func handleRateLimit() {
+	// Show the quota of the current repository's host, if any
+	client, err := github.NewClientForHost(defaultGitHubHost())
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Failed to create GitHub client: %v", err)))
+		return
+	}
+
+	limits, err := client.RateLimits()
+	if err != nil {
+		printGitHubError("Failed to get rate limits", err)
+		return
+	}
+
+	fmt.Println(ui.FormatInfo("GitHub API Rate Limits"))
+	printRateLimit("Core", limits.Core)
+	printRateLimit("Search", limits.Search)
+	printRateLimit("GraphQL", limits.GraphQL)
+}
+
+func printRateLimit(name string, limit github.RateLimit) {
+	if limit.Limit == 0 {
+		fmt.Printf("%s %-8s unavailable\n", ui.IconInfo, name+":")
+		return
+	}
+
+	line := fmt.Sprintf("%-8s %d/%d remaining, resets %s", name+":", limit.Remaining, limit.Limit, formatReset(limit.Reset))
+	switch {
+	case limit.Remaining == 0:
+		fmt.Println(ui.FormatError(line))
+	case limit.Remaining*10 < limit.Limit:
+		fmt.Println(ui.FormatWarning(line))
+	default:
+		fmt.Printf("%s %s\n", ui.IconInfo, line)
+	}
+}
+
+// formatReset describes a rate limit reset time relative to now
+func formatReset(reset time.Time) string {
+	wait := time.Until(reset).Round(time.Second)
+	if wait <= 0 {
+		return "now"
+	}
+	return fmt.Sprintf("in %s (%s)", wait, reset.Local().Format("15:04:05"))
+}
+
+// printGitHubError reports a failed GitHub API call together with a hint
+// for the typed errors the client returns
+func printGitHubError(message string, err error) {
+	fmt.Println(ui.FormatError(fmt.Sprintf("%s: %v", message, err)))
+
+	var (
+		notFound     *github.NotFoundError
+		unauthorized *github.UnauthorizedError
+		rateLimited  *github.RateLimitedError
+		validation   *github.ValidationError
+		conflict     *github.ConflictError
+	)
+	switch {
+	case errors.Is(err, github.ErrOffline):
+		fmt.Println(ui.FormatInfo("This data has not been cached yet; disable offline mode or reconnect to fetch it"))
+	case errors.As(err, &rateLimited):
+		if rateLimited.Reset.IsZero() {
+			fmt.Println(ui.FormatInfo("GitHub is throttling requests, wait a moment and try again"))
+		} else {
+			fmt.Println(ui.FormatInfo(fmt.Sprintf("Try again %s", formatReset(rateLimited.Reset))))
+		}
+	case errors.As(err, &unauthorized):
+		if unauthorized.Forbidden {
+			fmt.Println(ui.FormatInfo("Your token may lack the required scopes for this operation"))
+		} else {
+			fmt.Println(ui.FormatInfo("Check that your GitHub token is valid and has not expired"))
+		}
+	case errors.As(err, &conflict):
+		fmt.Println(ui.FormatInfo("The pull request changed or cannot be merged right now; review it and try again"))
+	case errors.As(err, &notFound):
+		fmt.Println(ui.FormatInfo("Check the repository name, or that your token can access private repositories"))
+	case errors.As(err, &validation):
+		for _, field := range validation.Fields {
+			if field.Field != "" {
+				fmt.Printf("  %s %s: %s\n", ui.IconWarning, field.Field, field.Code)
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+/*
+ * GitHubber - CLI Reflog Browser
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Interactive reflog viewer for recovering lost commits and branches
+ */
+
+package cli
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ritankarsaha/git-tool/internal/git"
+	"github.com/ritankarsaha/git-tool/internal/ui"
+)
+
+func handleReflog() {
+	ref := GetInput(ui.FormatPrompt("Enter branch name (press enter for HEAD): "))
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	for {
+		entries, err := git.ListReflog(ref, 30)
+		if err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("Error reading reflog: %v", err)))
+			return
+		}
+
+		if len(entries) == 0 {
+			fmt.Println(ui.FormatInfo(fmt.Sprintf("No reflog entries for %s", ref)))
+			return
+		}
+
+		fmt.Println(ui.FormatInfo(fmt.Sprintf("Reflog of %s", ref)))
+		for i, entry := range entries {
+			fmt.Printf("%2d. %s %s → %s %s: %s (%s)\n",
+				i+1, entry.Selector, shortHash(entry.OldHash), shortHash(entry.NewHash),
+				entry.Action, entry.Message, entry.Date.Format("2006-01-02 15:04"))
+		}
+
+		choice := GetInput(ui.FormatPrompt("Select an entry (press enter to go back): "))
+		if choice == "" {
+			return
+		}
+
+		n, err := strconv.Atoi(choice)
+		if err != nil || n < 1 || n > len(entries) {
+			fmt.Println(ui.FormatError("Invalid choice"))
+			continue
+		}
+
+		handleReflogEntry(ref, entries[n-1])
+	}
+}
+
+func handleReflogEntry(ref string, entry git.ReflogEntry) {
+	fmt.Println(ui.FormatInfo(fmt.Sprintf("%s: %s %s", entry.Selector, entry.Action, entry.Message)))
+	fmt.Println("1. Inspect commit")
+	fmt.Println("2. Restore a branch to this commit")
+	fmt.Println("3. Create branch from this commit")
+	fmt.Println("4. Back to reflog")
+
+	choice := GetInput(ui.FormatPrompt("Enter your choice (1-4): "))
+
+	switch choice {
+	case "1":
+		show, err := git.ShowCommit(entry.NewHash)
+		if err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("Error showing commit: %v", err)))
+			return
+		}
+		fmt.Printf("\n📝 %s:\n%s\n", entry.Selector, show)
+	case "2":
+		branch := ref
+		if branch == "HEAD" {
+			current, err := git.CurrentBranch()
+			if err != nil {
+				fmt.Println(ui.FormatError(fmt.Sprintf("Cannot restore: %v", err)))
+				return
+			}
+			branch = current
+		}
+		if name := GetInput(ui.FormatPrompt(fmt.Sprintf("Branch to restore (default: %s): ", branch))); name != "" {
+			branch = name
+		}
+		if !GetConfirmation(ui.FormatPrompt(fmt.Sprintf("Move %s to %s? Commits after it stay reachable from the reflog (y/N): ", branch, shortHash(entry.NewHash)))) {
+			fmt.Println(ui.FormatInfo("Restore cancelled"))
+			return
+		}
+		if err := git.RestoreBranch(branch, entry.NewHash); err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("Error restoring branch: %v", err)))
+			return
+		}
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("Restored %s to %s", branch, shortHash(entry.NewHash))))
+	case "3":
+		name := GetInput(ui.FormatPrompt("Enter new branch name: "))
+		if name == "" {
+			fmt.Println(ui.FormatError("Branch name cannot be empty"))
+			return
+		}
+		if err := git.CreateBranchAt(name, entry.NewHash); err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("Error creating branch: %v", err)))
+			return
+		}
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("Created branch %s at %s", name, shortHash(entry.NewHash))))
+	case "4":
+		return
+	default:
+		fmt.Println(ui.FormatError("Invalid choice"))
+	}
+}
@@ -0,0 +1,76 @@
+/*
+ * GitHubber - CLI Release Workflow
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Guided semantic version bump and release tagging
+ */
+
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ritankarsaha/git-tool/internal/git"
+	"github.com/ritankarsaha/git-tool/internal/ui"
+)
+
+func handleRelease() {
+	prerelease := GetInput(ui.FormatPrompt("Enter pre-release identifier, e.g. rc or beta (press enter for a stable release): "))
+
+	plan, err := git.PlanRelease(prerelease)
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Error planning release: %v", err)))
+		return
+	}
+
+	previous := plan.PreviousTag
+	if previous == "" {
+		previous = "(no previous release)"
+	}
+	fmt.Println(ui.FormatInfo("Release Plan"))
+	fmt.Println(ui.FormatBox(fmt.Sprintf(
+		"Latest release: %s\nCommits since: %d\nProposed bump: %s\nNext version: %s",
+		previous, len(plan.Commits), plan.Bump, plan.Next,
+	)))
+
+	override := GetInput(ui.FormatPrompt(fmt.Sprintf("Bump type (major/minor/patch, press enter to keep %s): ", plan.Bump)))
+	if override != "" {
+		bump, err := git.ParseBumpType(override)
+		if err != nil {
+			fmt.Println(ui.FormatError(err.Error()))
+			return
+		}
+		tags, err := git.ListTags()
+		if err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("Error listing tags: %v", err)))
+			return
+		}
+		plan.SetBump(bump, prerelease, tags)
+	}
+
+	fmt.Println(ui.FormatInfo("Release notes"))
+	fmt.Println(ui.FormatBox(plan.TagMessage()))
+
+	if !GetConfirmation(ui.FormatPrompt(fmt.Sprintf("Create tag %s at HEAD? (y/N): ", plan.Next))) {
+		fmt.Println(ui.FormatInfo("Release cancelled"))
+		return
+	}
+
+	if err := git.CreateReleaseTag(plan); err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Error creating release tag: %v", err)))
+		return
+	}
+	fmt.Println(ui.FormatSuccess(fmt.Sprintf("Created release tag %s", plan.Next)))
+
+	if !GetConfirmation(ui.FormatPrompt("Push the tag now? (y/N): ")) {
+		return
+	}
+	remote, ok := selectRemote("Select remote")
+	if !ok {
+		return
+	}
+	if err := git.PushTag(remote, plan.Next.String()); err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Error pushing tag: %v", err)))
+		return
+	}
+	fmt.Println(ui.FormatSuccess(fmt.Sprintf("Pushed %s to %s", plan.Next, remote)))
+}
@@ -0,0 +1,201 @@
+/*
+ * GitHubber - CLI Remote Management
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Remote listing, management and remote selection prompts
+ */
+
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ritankarsaha/git-tool/internal/git"
+	"github.com/ritankarsaha/git-tool/internal/github"
+	"github.com/ritankarsaha/git-tool/internal/ui"
+)
+
+// selectRemote asks the user to pick a configured remote by number or name.
+// Pressing enter picks the remote the current branch tracks. A repository
+// with a single remote uses it without asking.
+func selectRemote(prompt string) (string, bool) {
+	names, err := git.RemoteNames()
+	if err != nil || len(names) == 0 {
+		fmt.Println(ui.FormatError("No remotes configured; add one under Manage Remotes"))
+		return "", false
+	}
+	if len(names) == 1 {
+		return names[0], true
+	}
+
+	defaultRemote, err := git.CurrentRemote()
+	if err != nil {
+		defaultRemote = names[0]
+	}
+
+	for i, name := range names {
+		fmt.Printf("%d. %s\n", i+1, name)
+	}
+	choice := GetInput(ui.FormatPrompt(fmt.Sprintf("%s (default: %s): ", prompt, defaultRemote)))
+	if choice == "" {
+		return defaultRemote, true
+	}
+	if n, err := strconv.Atoi(choice); err == nil && n >= 1 && n <= len(names) {
+		return names[n-1], true
+	}
+	for _, name := range names {
+		if name == choice {
+			return name, true
+		}
+	}
+
+	fmt.Println(ui.FormatError(fmt.Sprintf("Unknown remote: %s", choice)))
+	return "", false
+}
+
+// githubRepository returns the GitHub repository behind remote, which may
+// live on github.com or a GitHub Enterprise host
+func githubRepository(remote string) (*github.RepoRef, error) {
+	url, err := git.RemoteURL(remote)
+	if err != nil {
+		return nil, err
+	}
+	return parseGitHubRepository(url)
+}
+
+// parseGitHubRepository parses a remote URL like github.ParseRepoRef, but
+// only accepts hosts the configuration knows as GitHub: github.com, the
+// default host and the hosts under github.hosts
+func parseGitHubRepository(url string) (*github.RepoRef, error) {
+	repo, err := github.ParseRepoRef(url)
+	if err != nil {
+		return nil, err
+	}
+	if !loadConfigOrDefault().IsGitHubHost(repo.Host) {
+		return nil, fmt.Errorf("%s is not a known GitHub host; add it under github.hosts in the configuration to use it as GitHub Enterprise", repo.Host)
+	}
+	return repo, nil
+}
+
+// pullRequestTarget returns the repository pull requests for branch are
+// opened against and the head to use. With both origin and upstream
+// remotes the branch lives in the fork, so the head is "<fork owner>:<branch>".
+func pullRequestTarget(branch string) (repo *github.RepoRef, head string, err error) {
+	base, err := git.BaseRemote()
+	if err != nil {
+		return nil, "", err
+	}
+	repo, err = githubRepository(base)
+	if err != nil {
+		return nil, "", err
+	}
+
+	head = branch
+	if base == "upstream" && git.HasRemote("origin") {
+		fork, err := githubRepository("origin")
+		if err != nil {
+			return nil, "", err
+		}
+		if fork.Owner != repo.Owner {
+			head = fork.Owner + ":" + branch
+		}
+	}
+	return repo, head, nil
+}
+
+func handleRemotes() {
+	remotes, err := git.ListRemotes()
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Error listing remotes: %v", err)))
+		return
+	}
+
+	if len(remotes) == 0 {
+		fmt.Println(ui.FormatInfo("No remotes configured"))
+	} else {
+		fmt.Println(ui.FormatInfo("Remotes"))
+		for _, remote := range remotes {
+			fmt.Printf("%s %s\n", ui.IconRemote, remote.Name)
+			fmt.Printf("   fetch: %s\n", remote.FetchURL)
+			if remote.PushURL != remote.FetchURL {
+				fmt.Printf("   push:  %s\n", remote.PushURL)
+			}
+			if len(remote.Branches) > 0 {
+				fmt.Printf("   branches: %s\n", strings.Join(remote.Branches, ", "))
+			}
+		}
+	}
+
+	fmt.Println("1. Add remote")
+	fmt.Println("2. Remove remote")
+	fmt.Println("3. Rename remote")
+	fmt.Println("4. Change remote URL")
+	fmt.Println("5. Back to main menu")
+
+	choice := GetInput(ui.FormatPrompt("Enter your choice (1-5): "))
+
+	switch choice {
+	case "1":
+		name := GetInput(ui.FormatPrompt("Enter remote name (e.g. upstream): "))
+		url := GetInput(ui.FormatPrompt("Enter remote URL: "))
+		if name == "" || url == "" {
+			fmt.Println(ui.FormatError("Remote name and URL are required"))
+			return
+		}
+		if err := git.AddRemote(name, url); err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("%v", err)))
+			return
+		}
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("Remote %s added", name)))
+	case "2":
+		name, ok := selectRemote("Remote to remove")
+		if !ok {
+			return
+		}
+		if !GetConfirmation(ui.FormatPrompt(fmt.Sprintf("Remove %s and its remote-tracking branches? (y/N): ", name))) {
+			fmt.Println(ui.FormatInfo("Remove cancelled"))
+			return
+		}
+		if err := git.RemoveRemote(name); err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("%v", err)))
+			return
+		}
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("Remote %s removed", name)))
+	case "3":
+		name, ok := selectRemote("Remote to rename")
+		if !ok {
+			return
+		}
+		newName := GetInput(ui.FormatPrompt("Enter new name: "))
+		if newName == "" {
+			fmt.Println(ui.FormatError("Remote name cannot be empty"))
+			return
+		}
+		if err := git.RenameRemote(name, newName); err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("%v", err)))
+			return
+		}
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("Remote %s renamed to %s", name, newName)))
+	case "4":
+		name, ok := selectRemote("Remote to change")
+		if !ok {
+			return
+		}
+		url := GetInput(ui.FormatPrompt("Enter new URL: "))
+		if url == "" {
+			fmt.Println(ui.FormatError("URL cannot be empty"))
+			return
+		}
+		pushOnly := GetConfirmation(ui.FormatPrompt("Change only the push URL? (y/N): "))
+		if err := git.SetRemoteURL(name, url, pushOnly); err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("%v", err)))
+			return
+		}
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("URL of %s updated", name)))
+	case "5":
+		return
+	default:
+		fmt.Println(ui.FormatError("Invalid choice"))
+	}
+}
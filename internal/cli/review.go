@@ -0,0 +1,288 @@
+/*
+ * GitHubber - CLI Code Review
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Reviewing pull requests hunk by hunk with line comments, suggestions and resumable pending reviews
+ */
+
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ritankarsaha/git-tool/internal/config"
+	"github.com/ritankarsaha/git-tool/internal/github"
+	"github.com/ritankarsaha/git-tool/internal/ui"
+)
+
+func handleReviewPR() {
+	repo, client, ok := openGitHubRepository()
+	if !ok {
+		return
+	}
+	number, ok := selectPullRequest(client, repo)
+	if !ok {
+		return
+	}
+
+	pr, err := client.GetPullRequest(repo.Owner, repo.Name, number)
+	if err != nil {
+		printGitHubError("Failed to get pull request", err)
+		return
+	}
+	files, err := client.PullRequestFiles(repo.Owner, repo.Name, number)
+	if err != nil {
+		printGitHubError("Failed to get pull request files", err)
+		return
+	}
+
+	dir, err := config.GetReviewsDir()
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("%v", err)))
+		return
+	}
+	review, found, err := github.LoadPendingReview(dir, repo, number, pr.HeadSHA)
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("%v", err)))
+		return
+	}
+
+	fmt.Println(ui.FormatTitle(fmt.Sprintf("Reviewing #%d %s", pr.Number, pr.Title)))
+	if found {
+		fmt.Println(ui.FormatInfo(fmt.Sprintf("Resuming your review with %d pending comments (saved %s)",
+			len(review.Comments), review.UpdatedAt.Local().Format("2006-01-02 15:04"))))
+		if review.CommitID != pr.HeadSHA {
+			fmt.Println(ui.FormatWarning(fmt.Sprintf("New commits were pushed since; your comments refer to %s", shortHash(review.CommitID))))
+			if GetConfirmation(ui.FormatPrompt(fmt.Sprintf("Move your comments to the latest commit %s? Commented lines may have shifted (y/N): ", shortHash(pr.HeadSHA)))) {
+				review.CommitID = pr.HeadSHA
+				saveReview(review, dir)
+			}
+		}
+		if !GetConfirmation(ui.FormatPrompt("Step through the diff again? (y/N): ")) {
+			finishReview(client, repo, review, dir)
+			return
+		}
+	}
+
+	fmt.Println(ui.FormatInfo("For each hunk: [enter] next hunk, c comment, s suggest a change, f next file, q finish reviewing"))
+	reviewFiles(review, files, dir)
+	finishReview(client, repo, review, dir)
+}
+
+// reviewFiles steps through the hunks of each file, collecting comments
+func reviewFiles(review *github.PendingReview, files []*github.PullRequestFile, dir string) {
+	for i, file := range files {
+		fmt.Println()
+		fmt.Println(ui.FormatMenuHeader(ui.IconInfo, fmt.Sprintf("[%d/%d] %s %s", i+1, len(files), file.Filename, ui.FormatDiffstat(file.Additions, file.Deletions, 20))))
+
+		hunks, err := github.ParsePatch(file.Patch)
+		if err != nil || len(hunks) == 0 {
+			fmt.Println(ui.FormatInfo("No diff available (binary file, rename only, or too large to show)"))
+			continue
+		}
+
+	hunks:
+		for _, hunk := range hunks {
+			printHunk(hunk, review.FileComments(file.Filename))
+			for {
+				switch strings.ToLower(GetInput(ui.FormatPrompt("Review (enter/c/s/f/q): "))) {
+				case "", "n":
+					continue hunks
+				case "c":
+					addReviewComment(review, file.Filename, hunk, false, dir)
+				case "s":
+					addReviewComment(review, file.Filename, hunk, true, dir)
+				case "f":
+					break hunks
+				case "q":
+					return
+				default:
+					fmt.Println(ui.FormatError("Unknown command; use enter, c, s, f or q"))
+				}
+			}
+		}
+	}
+}
+
+// printHunk prints a diff hunk with line numbers, followed on each line by
+// the pending comments ending there
+func printHunk(hunk *github.DiffHunk, comments []*github.ReviewComment) {
+	fmt.Println(ui.FormatDiffHunkHeader(hunk.Header))
+	for _, line := range hunk.Lines {
+		fmt.Println(ui.FormatDiffLine(line.OldLine, line.NewLine, string(line.Kind)+line.Content))
+		for _, comment := range comments {
+			if hunk.Line(comment.Side, comment.Line) == line {
+				fmt.Printf("           %s %s\n", "💬", firstLine(comment.Body, 70))
+			}
+		}
+	}
+}
+
+// parseLineRange parses "12", "12-15" or, for removed lines, "-12" and
+// "-12-15" into the diff side and the first and last line
+func parseLineRange(spec string) (side string, start, end int, err error) {
+	side = github.SideRight
+	if rest, ok := strings.CutPrefix(spec, "-"); ok {
+		side, spec = github.SideLeft, rest
+	}
+
+	first, last, isRange := strings.Cut(spec, "-")
+	if start, err = strconv.Atoi(strings.TrimSpace(first)); err != nil || start <= 0 {
+		return "", 0, 0, fmt.Errorf("invalid line number: %s", first)
+	}
+	end = start
+	if isRange {
+		if end, err = strconv.Atoi(strings.TrimSpace(last)); err != nil || end < start {
+			return "", 0, 0, fmt.Errorf("invalid line range: %s", spec)
+		}
+	}
+	return side, start, end, nil
+}
+
+// addReviewComment asks for the lines and text of a comment or suggestion
+// on hunk and adds it to the pending review
+func addReviewComment(review *github.PendingReview, path string, hunk *github.DiffHunk, suggest bool, dir string) {
+	spec := GetInput(ui.FormatPrompt("Line or range (e.g. 12 or 12-15, prefix removed lines with -): "))
+	side, start, end, err := parseLineRange(spec)
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("%v", err)))
+		return
+	}
+	// GitHub only accepts comments on lines shown in the same hunk
+	var lines []*github.DiffLine
+	for n := start; n <= end; n++ {
+		line := hunk.Line(side, n)
+		if line == nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("Line %d is not part of this hunk", n)))
+			return
+		}
+		lines = append(lines, line)
+	}
+
+	var body string
+	if suggest {
+		if side == github.SideLeft {
+			fmt.Println(ui.FormatError("Suggestions can only replace lines of the new version"))
+			return
+		}
+		fmt.Println(ui.FormatInfo("Current lines:"))
+		for _, line := range lines {
+			fmt.Println(line.Content)
+		}
+		replacement := GetCodeInput(ui.FormatPrompt("Enter the replacement (empty to delete the lines; finish with a line containing only '.'):"))
+		explanation := GetMultilineInput(ui.FormatPrompt("Explain the suggestion (optional; finish with a line containing only '.'):"))
+		body = github.Suggestion(explanation, replacement)
+	} else {
+		body = GetMultilineInput(ui.FormatPrompt("Enter your comment (finish with a line containing only '.'):"))
+	}
+	if body == "" {
+		fmt.Println(ui.FormatInfo("Empty comment discarded"))
+		return
+	}
+
+	comment := &github.ReviewComment{Path: path, Side: side, Line: end, Body: body}
+	if start != end {
+		comment.StartLine = start
+	}
+	review.Comments = append(review.Comments, comment)
+	saveReview(review, dir)
+	fmt.Println(ui.FormatSuccess(fmt.Sprintf("Comment added (%d pending)", len(review.Comments))))
+}
+
+// saveReview saves the pending review, warning if that fails
+func saveReview(review *github.PendingReview, dir string) {
+	if err := review.Save(dir); err != nil {
+		fmt.Println(ui.FormatWarning(fmt.Sprintf("%v", err)))
+	}
+}
+
+// commentLocation describes the lines a review comment refers to
+func commentLocation(comment *github.ReviewComment) string {
+	prefix := ""
+	if comment.Side == github.SideLeft {
+		prefix = "-"
+	}
+	if comment.StartLine != 0 {
+		return fmt.Sprintf("%s:%s%d-%d", comment.Path, prefix, comment.StartLine, comment.Line)
+	}
+	return fmt.Sprintf("%s:%s%d", comment.Path, prefix, comment.Line)
+}
+
+// finishReview submits, saves or discards the pending review
+func finishReview(client *github.Client, repo *github.RepoRef, review *github.PendingReview, dir string) {
+	events := map[string]string{
+		"a": github.ReviewEventApprove,
+		"r": github.ReviewEventRequestChanges,
+		"c": github.ReviewEventComment,
+	}
+
+	for {
+		fmt.Println()
+		fmt.Println(ui.FormatInfo(fmt.Sprintf("%d pending comments", len(review.Comments))))
+		for i, comment := range review.Comments {
+			fmt.Printf("  %d. %s %s\n", i+1, commentLocation(comment), firstLine(comment.Body, 60))
+		}
+
+		choice := strings.ToLower(GetInput(ui.FormatPrompt("Submit as (a)pprove, (r)equest changes or (c)omment; (d)elete a comment, (s)ave for later, (x) discard: ")))
+		switch choice {
+		case "a", "r", "c":
+			event := events[choice]
+			prompt := "Review summary (finish with a line containing only '.'):"
+			if event == github.ReviewEventApprove {
+				prompt = "Review summary (optional; finish with a line containing only '.'):"
+			}
+			if review.Body != "" {
+				fmt.Println(ui.FormatInfo(fmt.Sprintf("Saved summary: %s", firstLine(review.Body, 60))))
+				prompt = "Review summary (leave empty to keep the saved one; finish with a line containing only '.'):"
+			}
+			if body := GetMultilineInput(ui.FormatPrompt(prompt)); body != "" {
+				review.Body = body
+			}
+			if review.Body == "" && event != github.ReviewEventApprove {
+				fmt.Println(ui.FormatError("A summary is required when requesting changes or commenting"))
+				continue
+			}
+			saveReview(review, dir)
+
+			submitted, err := client.SubmitReview(repo.Owner, repo.Name, review.Number, review.CommitID, event, review.Body, review.Comments)
+			if err != nil {
+				printGitHubError("Failed to submit review", err)
+				fmt.Println(ui.FormatInfo("Your review is saved; resume it later with Review Pull Request"))
+				return
+			}
+			if err := review.Delete(dir); err != nil {
+				fmt.Println(ui.FormatWarning(fmt.Sprintf("%v", err)))
+			}
+			fmt.Println(ui.FormatSuccess(fmt.Sprintf("Review submitted (%s) with %d comments", strings.ToLower(strings.ReplaceAll(submitted.State, "_", " ")), len(review.Comments))))
+			return
+
+		case "d":
+			n, err := strconv.Atoi(GetInput(ui.FormatPrompt("Comment number to delete: ")))
+			if err != nil || n < 1 || n > len(review.Comments) {
+				fmt.Println(ui.FormatError("Invalid comment number"))
+				continue
+			}
+			review.Comments = append(review.Comments[:n-1], review.Comments[n:]...)
+			saveReview(review, dir)
+
+		case "s", "":
+			saveReview(review, dir)
+			fmt.Println(ui.FormatSuccess(fmt.Sprintf("Review saved; choose Review Pull Request for #%d to resume it", review.Number)))
+			return
+
+		case "x":
+			if !GetConfirmation(ui.FormatPrompt(fmt.Sprintf("Discard %d pending comments? (y/N): ", len(review.Comments)))) {
+				continue
+			}
+			if err := review.Delete(dir); err != nil {
+				fmt.Println(ui.FormatWarning(fmt.Sprintf("%v", err)))
+			}
+			fmt.Println(ui.FormatInfo("Review discarded"))
+			return
+
+		default:
+			fmt.Println(ui.FormatError("Unknown choice"))
+		}
+	}
+}
@@ -0,0 +1,128 @@
+/*
+ * GitHubber - CLI History Fixes
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Amend, reword and undo-last-commit handlers
+ */
+
+package cli
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ritankarsaha/git-tool/internal/git"
+	"github.com/ritankarsaha/git-tool/internal/ui"
+)
+
+// confirmRewrite warns when commit has already been pushed to the upstream
+// and reports whether the user wants to continue and whether it was pushed
+func confirmRewrite(commit string) (proceed, pushed bool) {
+	pushed, err := git.IsPushed(commit)
+	if err != nil || !pushed {
+		return true, false
+	}
+	fmt.Println(ui.FormatWarning("This commit has already been pushed to the upstream."))
+	fmt.Println(ui.FormatWarning("Rewriting it will require a force push and affects anyone who pulled it."))
+	return GetConfirmation(ui.FormatPrompt("Continue anyway? (y/N): ")), true
+}
+
+func handleAmendCommit() {
+	proceed, pushed := confirmRewrite("HEAD")
+	if !proceed {
+		fmt.Println(ui.FormatInfo("Amend cancelled"))
+		return
+	}
+
+	if staged, _ := git.RunCommand("git diff --cached --name-only"); staged != "" {
+		fmt.Println(ui.FormatInfo("Staged changes to add to the last commit:\n" + staged))
+	} else {
+		fmt.Println(ui.FormatInfo("No staged changes; only the message can be changed"))
+	}
+
+	message := ""
+	if GetConfirmation(ui.FormatPrompt("Edit the commit message? (y/N): ")) {
+		current, _ := git.RunCommand("git log -1 --format=%B")
+		fmt.Println(ui.FormatBox(current))
+		message = GetMultilineInput(ui.FormatPrompt("Enter new commit message (finish with a line containing only '.'):"))
+		if message == "" {
+			fmt.Println(ui.FormatError("Commit message cannot be empty"))
+			return
+		}
+	}
+
+	if err := git.AmendCommit(message); err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Error amending commit: %v", err)))
+		return
+	}
+	fmt.Println(ui.FormatSuccess("Last commit amended successfully!"))
+	if pushed {
+		pushRewrittenBranch()
+	}
+}
+
+func handleRewordCommit() {
+	commits, err := git.GetRecentCommits(10)
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Error fetching commits: %v", err)))
+		return
+	}
+	if len(commits) == 0 {
+		fmt.Println(ui.FormatInfo("No commits to reword"))
+		return
+	}
+
+	fmt.Println(ui.FormatInfo("Recent Commits"))
+	for i, commit := range commits {
+		fmt.Printf("%d. %s: %s\n", i+1, commit.Hash, commit.Message)
+	}
+	index, err := strconv.Atoi(GetInput(ui.FormatPrompt("Enter the number of the commit to reword: ")))
+	if err != nil || index < 1 || index > len(commits) {
+		fmt.Println(ui.FormatError("Invalid selection"))
+		return
+	}
+	commit := commits[index-1]
+
+	proceed, pushed := confirmRewrite(commit.Hash)
+	if !proceed {
+		fmt.Println(ui.FormatInfo("Reword cancelled"))
+		return
+	}
+
+	current, _ := git.RunCommand(fmt.Sprintf("git log -1 --format=%%B %s", commit.Hash))
+	fmt.Println(ui.FormatBox(current))
+	message := GetMultilineInput(ui.FormatPrompt("Enter new commit message (finish with a line containing only '.'):"))
+	if message == "" {
+		fmt.Println(ui.FormatError("Commit message cannot be empty"))
+		return
+	}
+
+	if err := git.RewordCommit(commit.Hash, message); err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Error rewording commit: %v", err)))
+		return
+	}
+	fmt.Println(ui.FormatSuccess(fmt.Sprintf("Commit %s reworded successfully!", commit.Hash)))
+	if pushed {
+		pushRewrittenBranch()
+	}
+}
+
+func handleUndoCommit() {
+	subject, err := git.RunCommand("git log -1 --format=%s")
+	if err != nil {
+		fmt.Println(ui.FormatError("No commit to undo"))
+		return
+	}
+	if proceed, _ := confirmRewrite("HEAD"); !proceed {
+		fmt.Println(ui.FormatInfo("Undo cancelled"))
+		return
+	}
+	if !GetConfirmation(ui.FormatPrompt(fmt.Sprintf("Undo \"%s\" and keep its changes staged? (y/N): ", subject))) {
+		return
+	}
+
+	if err := git.UndoLastCommit(); err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Error undoing commit: %v", err)))
+		return
+	}
+	fmt.Println(ui.FormatSuccess("Last commit undone; its changes are staged"))
+}
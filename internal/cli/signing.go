@@ -0,0 +1,92 @@
+/*
+ * GitHubber - CLI Signing Support
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Signing preferences and commit signature verification view
+ */
+
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ritankarsaha/git-tool/internal/config"
+	"github.com/ritankarsaha/git-tool/internal/git"
+	"github.com/ritankarsaha/git-tool/internal/ui"
+)
+
+// applyGitSettings passes the git related configuration to the git package
+func applyGitSettings(cfg *config.Config) {
+	err := git.ConfigureSigning(git.SigningConfig{
+		Enabled:            cfg.Git.SignCommits,
+		Format:             cfg.Git.SigningFormat,
+		Key:                cfg.Git.SigningKey,
+		AllowedSignersFile: cfg.Git.AllowedSignersFile,
+	})
+	if err != nil {
+		fmt.Println(ui.FormatWarning(fmt.Sprintf("Commit signing disabled: %v", err)))
+	}
+}
+
+func setSigningPreferences(cfg *config.Config) {
+	cfg.Git.SignCommits = GetConfirmation(ui.FormatPrompt("Sign commits, tags and squash results? (y/N): "))
+
+	if cfg.Git.SignCommits {
+		format := GetInput(ui.FormatPrompt(fmt.Sprintf("Signing format (gpg/ssh/x509, default: %s): ", cfg.Git.SigningFormat)))
+		if format != "" {
+			cfg.Git.SigningFormat = format
+		}
+		key := GetInput(ui.FormatPrompt("Signing key (key ID, or public key path for ssh; press enter to keep current): "))
+		if key != "" {
+			cfg.Git.SigningKey = key
+		}
+		if cfg.Git.SigningFormat == "ssh" {
+			signers := GetInput(ui.FormatPrompt("Allowed signers file for verification (press enter to keep current): "))
+			if signers != "" {
+				cfg.Git.AllowedSignersFile = signers
+			}
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Invalid configuration: %v", err)))
+		return
+	}
+	if err := cfg.Save(); err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Failed to save configuration: %v", err)))
+		return
+	}
+
+	applyGitSettings(cfg)
+	fmt.Println(ui.FormatSuccess("Signing preferences saved successfully"))
+}
+
+func handleVerifySignatures() {
+	n := 20
+	signatures, err := git.VerifyCommitSignatures(n)
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Error verifying signatures: %v", err)))
+		return
+	}
+
+	fmt.Println(ui.FormatInfo(fmt.Sprintf("Signature status of the last %d commits", n)))
+	for _, sig := range signatures {
+		icon := ui.IconWarning
+		switch sig.Status {
+		case git.SignatureGood:
+			icon = ui.IconSuccess
+		case git.SignatureBad, git.SignatureRevokedKey:
+			icon = ui.IconError
+		case git.SignatureNone:
+			icon = "  "
+		}
+
+		line := fmt.Sprintf("%s %s %s — %s", icon, shortHash(sig.Hash), sig.Subject, sig.Status.Description())
+		if sig.Signer != "" {
+			line += " by " + sig.Signer
+		}
+		if sig.Key != "" {
+			line += " (key " + sig.Key + ")"
+		}
+		fmt.Println(line)
+	}
+}
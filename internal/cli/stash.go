@@ -0,0 +1,109 @@
+/*
+ * GitHubber - CLI Stash Browser
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Interactive browser for inspecting and managing stash entries
+ */
+
+package cli
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ritankarsaha/git-tool/internal/git"
+	"github.com/ritankarsaha/git-tool/internal/ui"
+)
+
+func handleStashBrowser() {
+	for {
+		stashes, err := git.ListStashes()
+		if err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("Error listing stashes: %v", err)))
+			return
+		}
+
+		if len(stashes) == 0 {
+			fmt.Println(ui.FormatInfo("No stashes found"))
+			return
+		}
+
+		fmt.Println(ui.FormatInfo("Stash Browser"))
+		for i, stash := range stashes {
+			fmt.Printf("%2d. %s [%s] %s (%d files, %s)\n",
+				i+1, stash.Ref, stash.Branch, stash.Message,
+				stash.FilesChanged, stash.Date.Format("2006-01-02 15:04"))
+		}
+
+		choice := GetInput(ui.FormatPrompt("Select a stash (press enter to go back): "))
+		if choice == "" {
+			return
+		}
+
+		n, err := strconv.Atoi(choice)
+		if err != nil || n < 1 || n > len(stashes) {
+			fmt.Println(ui.FormatError("Invalid choice"))
+			continue
+		}
+
+		handleStashEntry(stashes[n-1])
+	}
+}
+
+func handleStashEntry(stash git.StashEntry) {
+	fmt.Println(ui.FormatInfo(fmt.Sprintf("%s: %s", stash.Ref, stash.Message)))
+	fmt.Println("1. Show diff")
+	fmt.Println("2. Apply (keep stash)")
+	fmt.Println("3. Pop (apply and drop)")
+	fmt.Println("4. Drop")
+	fmt.Println("5. Create branch from stash")
+	fmt.Println("6. Back to stash list")
+
+	choice := GetInput(ui.FormatPrompt("Enter your choice (1-6): "))
+
+	switch choice {
+	case "1":
+		diff, err := git.ShowStash(stash.Index)
+		if err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("Error showing stash: %v", err)))
+			return
+		}
+		fmt.Printf("\n📝 %s:\n%s\n", stash.Ref, diff)
+	case "2":
+		if err := git.ApplyStash(stash.Index); err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("Error applying stash: %v", err)))
+			return
+		}
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("Applied %s", stash.Ref)))
+	case "3":
+		if err := git.PopStash(stash.Index); err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("Error popping stash: %v", err)))
+			return
+		}
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("Popped %s", stash.Ref)))
+	case "4":
+		if !GetConfirmation(ui.FormatPrompt(fmt.Sprintf("Drop %s? This cannot be undone (y/N): ", stash.Ref))) {
+			fmt.Println(ui.FormatInfo("Drop cancelled"))
+			return
+		}
+		if err := git.DropStash(stash.Index); err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("Error dropping stash: %v", err)))
+			return
+		}
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("Dropped %s", stash.Ref)))
+	case "5":
+		branch := GetInput(ui.FormatPrompt("Enter new branch name: "))
+		if branch == "" {
+			fmt.Println(ui.FormatError("Branch name cannot be empty"))
+			return
+		}
+		if err := git.StashBranch(branch, stash.Index); err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("Error creating branch from stash: %v", err)))
+			return
+		}
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("Created branch %s from %s", branch, stash.Ref)))
+	case "6":
+		return
+	default:
+		fmt.Println(ui.FormatError("Invalid choice"))
+	}
+}
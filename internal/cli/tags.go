@@ -0,0 +1,108 @@
+/*
+ * GitHubber - CLI Tag Management
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Tag formatting, pushing and remote tag comparison handlers
+ */
+
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ritankarsaha/git-tool/internal/git"
+	"github.com/ritankarsaha/git-tool/internal/ui"
+)
+
+// formatTag renders a tag with its target commit and annotation details
+func formatTag(tag git.Tag) string {
+	kind := "lightweight"
+	if tag.Annotated {
+		kind = "annotated"
+	}
+	if tag.Signed {
+		kind += ", signed"
+	}
+
+	line := fmt.Sprintf("%s %s → %s (%s, %s)",
+		ui.IconTag, tag.Name, shortHash(tag.Commit), kind, tag.Date.Format("2006-01-02"))
+	if tag.Tagger != "" {
+		line += " by " + tag.Tagger
+	}
+	if tag.Message != "" {
+		subject, _, _ := strings.Cut(tag.Message, "\n")
+		line += "\n    " + subject
+	}
+	return line
+}
+
+// shortHash abbreviates a full object name for display
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+func handlePushTags() {
+	remote, ok := selectRemote("Select remote")
+	if !ok {
+		return
+	}
+	name := GetInput("Enter tag name to push (press enter for all tags): ")
+
+	var err error
+	if name == "" {
+		err = git.PushAllTags(remote)
+	} else {
+		err = git.PushTag(remote, name)
+	}
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Error pushing tags: %v", err)))
+		return
+	}
+	fmt.Println(ui.FormatSuccess(fmt.Sprintf("Tags pushed to %s successfully!", remote)))
+}
+
+func handleCompareTags() {
+	remote, ok := selectRemote("Select remote")
+	if !ok {
+		return
+	}
+
+	comparison, err := git.CompareTags(remote)
+	if err != nil {
+		fmt.Println(ui.FormatError(fmt.Sprintf("Error comparing tags: %v", err)))
+		return
+	}
+
+	if len(comparison.LocalOnly) == 0 && len(comparison.RemoteOnly) == 0 {
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("Local tags are in sync with %s", remote)))
+		return
+	}
+
+	fmt.Println(ui.FormatInfo(fmt.Sprintf("Tag comparison with %s", remote)))
+	fmt.Println(ui.FormatBox(fmt.Sprintf(
+		"Missing on %s (%d):\n%s\n\nMissing locally (%d):\n%s",
+		remote, len(comparison.LocalOnly), formatNameList(comparison.LocalOnly),
+		len(comparison.RemoteOnly), formatNameList(comparison.RemoteOnly),
+	)))
+
+	if len(comparison.LocalOnly) > 0 && GetConfirmation(ui.FormatPrompt(fmt.Sprintf("Push missing tags to %s? (y/N): ", remote))) {
+		for _, name := range comparison.LocalOnly {
+			if err := git.PushTag(remote, name); err != nil {
+				fmt.Println(ui.FormatError(fmt.Sprintf("Error pushing %s: %v", name, err)))
+				return
+			}
+		}
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("Pushed %d tags to %s", len(comparison.LocalOnly), remote)))
+	}
+}
+
+// formatNameList renders names one per line, or a placeholder when empty
+func formatNameList(names []string) string {
+	if len(names) == 0 {
+		return "  (none)"
+	}
+	return "  " + strings.Join(names, "\n  ")
+}
@@ -9,8 +9,10 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 type Config struct {
@@ -24,6 +26,24 @@ type GitHubConfig struct {
 	DefaultOwner string `json:"default_owner"`          // Default repository owner
 	DefaultRepo  string `json:"default_repo"`           // Default repository name
 	APIBaseURL   string `json:"api_base_url,omitempty"` // For GitHub Enterprise
+	UploadURL    string `json:"upload_url,omitempty"`   // Enterprise upload endpoint, derived from api_base_url if empty
+	Offline      bool   `json:"offline"`                // Serve API responses from the cache only
+
+	// OAuthClientID identifies the OAuth app (with device flow enabled)
+	// used by 'githubber login'
+	OAuthClientID string `json:"oauth_client_id,omitempty"`
+
+	// Hosts maps repository hosts (e.g. "github.example.com") to their API
+	// endpoints and tokens, for working with several GitHub instances
+	Hosts map[string]HostConfig `json:"hosts,omitempty"`
+}
+
+// HostConfig holds the API endpoints and token of one GitHub host
+type HostConfig struct {
+	APIBaseURL    string `json:"api_base_url,omitempty"` // Defaults to https://<host>/api/v3/
+	UploadURL     string `json:"upload_url,omitempty"`
+	Token         string `json:"token,omitempty"`
+	OAuthClientID string `json:"oauth_client_id,omitempty"`
 }
 
 type UIConfig struct {
@@ -34,14 +54,33 @@ type UIConfig struct {
 }
 
 type GitConfig struct {
-	DefaultBranch string `json:"default_branch"` // Default branch name for new repos
-	AutoPush      bool   `json:"auto_push"`      // Automatically push commits
-	SignCommits   bool   `json:"sign_commits"`   // Sign commits with GPG
+	DefaultBranch      string            `json:"default_branch"`                 // Default branch name for new repos
+	AutoPush           bool              `json:"auto_push"`                      // Automatically push commits
+	SignCommits        bool              `json:"sign_commits"`                   // Sign commits, tags and squash results
+	SigningFormat      string            `json:"signing_format,omitempty"`       // Signature format (gpg, ssh, x509)
+	SigningKey         string            `json:"signing_key,omitempty"`          // Key ID, or public key path for ssh
+	AllowedSignersFile string            `json:"allowed_signers_file,omitempty"` // SSH allowed signers file for verification
+	CommitRules        CommitRulesConfig `json:"commit_rules"`                   // Commit message validation rules
+	PullMode           string            `json:"pull_mode"`                      // Default pull mode (merge, rebase, ff-only)
+	PullAutostash      bool              `json:"pull_autostash"`                 // Stash local changes around pulls
+}
+
+type CommitRulesConfig struct {
+	SubjectMaxLength int      `json:"subject_max_length"`           // Maximum length of the commit header
+	AllowedTypes     []string `json:"allowed_types,omitempty"`      // Allowed commit types, empty for the Conventional Commits set
+	AllowedScopes    []string `json:"allowed_scopes,omitempty"`     // Allowed scopes, empty for any scope
+	RequireScope     bool     `json:"require_scope"`                // Whether every commit needs a scope
+	DisableMoodCheck bool     `json:"disable_mood_check,omitempty"` // Skip the imperative mood heuristic
 }
 
 const (
 	configFileName = "githubber.json"
 	configDirName  = ".githubber"
+	cacheDirName   = "cache"
+	reviewsDirName = "reviews"
+
+	githubHost       = "github.com"
+	githubAPIBaseURL = "https://api.github.com"
 )
 
 // GetConfigPath returns the path to the configuration file
@@ -55,6 +94,27 @@ func GetConfigPath() (string, error) {
 	return filepath.Join(configDir, configFileName), nil
 }
 
+// GetCacheDir returns the directory cached GitHub API responses are stored in
+func GetCacheDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	return filepath.Join(homeDir, configDirName, cacheDirName), nil
+}
+
+// GetReviewsDir returns the directory unsubmitted pull request reviews are
+// saved in
+func GetReviewsDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	return filepath.Join(homeDir, configDirName, reviewsDirName), nil
+}
+
 // Load loads the configuration from file
 func Load() (*Config, error) {
 	configPath, err := GetConfigPath()
@@ -112,7 +172,7 @@ func (c *Config) Save() error {
 func GetDefaultConfig() *Config {
 	return &Config{
 		GitHub: GitHubConfig{
-			APIBaseURL: "https://api.github.com",
+			APIBaseURL: githubAPIBaseURL,
 		},
 		UI: UIConfig{
 			Theme:       "dark",
@@ -124,6 +184,11 @@ func GetDefaultConfig() *Config {
 			DefaultBranch: "main",
 			AutoPush:      false,
 			SignCommits:   false,
+			SigningFormat: "gpg",
+			PullMode:      "merge",
+			CommitRules: CommitRulesConfig{
+				SubjectMaxLength: 72,
+			},
 		},
 	}
 }
@@ -147,6 +212,15 @@ func fillDefaults(config *Config) {
 	if config.Git.DefaultBranch == "" {
 		config.Git.DefaultBranch = defaults.Git.DefaultBranch
 	}
+	if config.Git.SigningFormat == "" {
+		config.Git.SigningFormat = defaults.Git.SigningFormat
+	}
+	if config.Git.PullMode == "" {
+		config.Git.PullMode = defaults.Git.PullMode
+	}
+	if config.Git.CommitRules.SubjectMaxLength == 0 {
+		config.Git.CommitRules.SubjectMaxLength = defaults.Git.CommitRules.SubjectMaxLength
+	}
 }
 
 // SetGitHubToken sets the GitHub token in the configuration
@@ -155,20 +229,86 @@ func (c *Config) SetGitHubToken(token string) error {
 	return c.Save()
 }
 
-// GetGitHubToken gets the GitHub token from config or environment
-func (c *Config) GetGitHubToken() string {
-	// First check environment variable
-	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
-		return token
+// DefaultHost returns the host api_base_url belongs to: github.com unless
+// it points at a GitHub Enterprise server
+func (c *Config) DefaultHost() string {
+	apiURL, err := url.Parse(c.GitHub.APIBaseURL)
+	if err != nil || apiURL.Host == "" || apiURL.Host == "api.github.com" {
+		return githubHost
 	}
-	
-	// Then check config file
-	return c.GitHub.Token
+	return apiURL.Host
+}
+
+// IsGitHubHost reports whether host is github.com, the default host or a
+// host configured in Hosts. Remotes on other hosts are not treated as GitHub.
+func (c *Config) IsGitHubHost(host string) bool {
+	if strings.EqualFold(host, githubHost) || strings.EqualFold(host, c.DefaultHost()) {
+		return true
+	}
+	for configured := range c.GitHub.Hosts {
+		if strings.EqualFold(host, configured) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetHostConfig returns the API endpoints and configured token for
+// repositories on host, or on the default host if host is empty. Entries in
+// Hosts take precedence; the top-level settings apply to the default host,
+// and other hosts are assumed to be GitHub Enterprise servers. Tokens from
+// other sources are resolved by the github package.
+func (c *Config) GetHostConfig(host string) HostConfig {
+	if host == "" {
+		host = c.DefaultHost()
+	}
+	hostConfig := c.GitHub.Hosts[host]
+
+	switch {
+	case host == c.DefaultHost():
+		if hostConfig.APIBaseURL == "" {
+			hostConfig.APIBaseURL = c.GitHub.APIBaseURL
+			hostConfig.UploadURL = c.GitHub.UploadURL
+		}
+		if hostConfig.Token == "" {
+			hostConfig.Token = c.GitHub.Token
+		}
+		if hostConfig.OAuthClientID == "" {
+			hostConfig.OAuthClientID = c.GitHub.OAuthClientID
+		}
+	case host == githubHost:
+		if hostConfig.APIBaseURL == "" {
+			hostConfig.APIBaseURL = githubAPIBaseURL
+		}
+	default:
+		if hostConfig.APIBaseURL == "" {
+			hostConfig.APIBaseURL = "https://" + host + "/api/v3/"
+		}
+	}
+
+	return hostConfig
 }
 
-// IsConfigured checks if the basic configuration is set up
-func (c *Config) IsConfigured() bool {
-	return c.GetGitHubToken() != ""
+// SetHostToken stores token (or removes it if empty) for host in the
+// configuration file. The default host's token is the top-level one.
+func (c *Config) SetHostToken(host, token string) error {
+	if host == "" || host == c.DefaultHost() {
+		hostConfig, ok := c.GitHub.Hosts[c.DefaultHost()]
+		if ok && hostConfig.Token != "" {
+			hostConfig.Token = token
+			c.GitHub.Hosts[c.DefaultHost()] = hostConfig
+		}
+		return c.SetGitHubToken(token)
+	}
+
+	// The entry is kept without a token, since it marks host as GitHub
+	hostConfig := c.GitHub.Hosts[host]
+	hostConfig.Token = token
+	if c.GitHub.Hosts == nil {
+		c.GitHub.Hosts = make(map[string]HostConfig)
+	}
+	c.GitHub.Hosts[host] = hostConfig
+	return c.Save()
 }
 
 // Validate validates the configuration
@@ -188,6 +328,50 @@ func (c *Config) Validate() error {
 	if !validTheme {
 		return fmt.Errorf("invalid theme: %s (valid themes: %v)", c.UI.Theme, validThemes)
 	}
+
+	switch c.Git.SigningFormat {
+	case "gpg", "ssh", "x509":
+	default:
+		return fmt.Errorf("invalid signing_format: %s (valid formats: gpg, ssh, x509)", c.Git.SigningFormat)
+	}
+
+	switch c.Git.PullMode {
+	case "merge", "rebase", "ff-only":
+	default:
+		return fmt.Errorf("invalid pull_mode: %s (valid modes: merge, rebase, ff-only)", c.Git.PullMode)
+	}
+
+	if c.Git.CommitRules.SubjectMaxLength < 0 {
+		return fmt.Errorf("subject_max_length must not be negative")
+	}
+
+	if err := validateURL("api_base_url", c.GitHub.APIBaseURL); err != nil {
+		return err
+	}
+	if err := validateURL("upload_url", c.GitHub.UploadURL); err != nil {
+		return err
+	}
+	for host, hostConfig := range c.GitHub.Hosts {
+		if err := validateURL("hosts."+host+".api_base_url", hostConfig.APIBaseURL); err != nil {
+			return err
+		}
+		if err := validateURL("hosts."+host+".upload_url", hostConfig.UploadURL); err != nil {
+			return err
+		}
+	}
 	
 	return nil
-}
\ No newline at end of file
+}
+
+// validateURL checks that an optional endpoint setting is an absolute
+// http(s) URL
+func validateURL(name, value string) error {
+	if value == "" {
+		return nil
+	}
+	endpoint, err := url.Parse(value)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return fmt.Errorf("invalid %s: %s (expected an http or https URL)", name, value)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package config
+
+import "testing"
+
+// testConfig returns a configuration with a top-level token and one
+// Enterprise host
+func testConfig() *Config {
+	cfg := GetDefaultConfig()
+	cfg.GitHub.Token = "default-token"
+	cfg.GitHub.Hosts = map[string]HostConfig{
+		"ghe.example.com": {APIBaseURL: "https://api.ghe.example.com/", Token: "ghe-token"},
+	}
+	return cfg
+}
+
+func TestGetHostConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		apiBaseURL string // Top-level api_base_url, empty for the default
+		host       string
+		wantBase   string
+		wantToken  string
+	}{
+		{"github.com", "", "github.com", "https://api.github.com", "default-token"},
+		{"default host", "", "", "https://api.github.com", "default-token"},
+		{"configured host", "", "ghe.example.com", "https://api.ghe.example.com/", "ghe-token"},
+		{"unconfigured host", "", "other.example.com", "https://other.example.com/api/v3/", ""},
+		{"enterprise default host", "https://corp.example.com/api/v3", "", "https://corp.example.com/api/v3", "default-token"},
+		{"github.com with enterprise default", "https://corp.example.com/api/v3", "github.com", "https://api.github.com", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig()
+			if tt.apiBaseURL != "" {
+				cfg.GitHub.APIBaseURL = tt.apiBaseURL
+			}
+			got := cfg.GetHostConfig(tt.host)
+			if got.APIBaseURL != tt.wantBase || got.Token != tt.wantToken {
+				t.Errorf("GetHostConfig(%q) = %+v, want %s with token %q", tt.host, got, tt.wantBase, tt.wantToken)
+			}
+		})
+	}
+
+	cfg := testConfig()
+	cfg.GitHub.APIBaseURL = "https://corp.example.com/api/v3"
+	if host := cfg.DefaultHost(); host != "corp.example.com" {
+		t.Errorf("DefaultHost() = %q, want corp.example.com", host)
+	}
+}
+
+func TestIsGitHubHost(t *testing.T) {
+	tests := []struct {
+		host       string
+		apiBaseURL string
+		want       bool
+	}{
+		{"github.com", "", true},
+		{"GitHub.com", "", true},
+		{"ghe.example.com", "", true},
+		{"GHE.example.com", "", true},
+		{"corp.example.com", "https://corp.example.com/api/v3", true},
+		{"corp.example.com", "", false},
+		{"gitlab.com", "", false},
+	}
+
+	for _, tt := range tests {
+		cfg := testConfig()
+		if tt.apiBaseURL != "" {
+			cfg.GitHub.APIBaseURL = tt.apiBaseURL
+		}
+		if got := cfg.IsGitHubHost(tt.host); got != tt.want {
+			t.Errorf("IsGitHubHost(%q) with api_base_url %q = %v, want %v", tt.host, tt.apiBaseURL, got, tt.want)
+		}
+	}
+}
+
+func TestSetHostToken(t *testing.T) {
+	tests := []struct {
+		name        string
+		defaultHost HostConfig // Hosts entry for github.com, if any
+		host        string
+		token       string
+		wantTop     string // Top-level token afterwards
+		wantHost    string // Token of the Hosts entry for host afterwards
+	}{
+		{name: "default host", host: "", token: "new", wantTop: "new"},
+		{name: "default host by name", host: "github.com", token: "new", wantTop: "new"},
+		{name: "default host entry with token", defaultHost: HostConfig{Token: "old"}, host: "github.com", token: "new", wantTop: "new", wantHost: "new"},
+		{name: "default host entry without token", defaultHost: HostConfig{OAuthClientID: "id"}, host: "github.com", token: "new", wantTop: "new"},
+		{name: "remove default token", host: "", token: "", wantTop: ""},
+		{name: "enterprise host", host: "ghe.example.com", token: "new", wantTop: "default-token", wantHost: "new"},
+		{name: "remove enterprise token", host: "ghe.example.com", token: "", wantTop: "default-token"},
+		{name: "new enterprise host", host: "new.example.com", token: "new", wantTop: "default-token", wantHost: "new"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+			cfg := testConfig()
+			if tt.defaultHost != (HostConfig{}) {
+				cfg.GitHub.Hosts["github.com"] = tt.defaultHost
+			}
+
+			if err := cfg.SetHostToken(tt.host, tt.token); err != nil {
+				t.Fatalf("SetHostToken() error = %v", err)
+			}
+			saved, err := Load()
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+
+			host := tt.host
+			if host == "" {
+				host = "github.com"
+			}
+			if saved.GitHub.Token != tt.wantTop {
+				t.Errorf("top-level token = %q, want %q", saved.GitHub.Token, tt.wantTop)
+			}
+			if got := saved.GitHub.Hosts[host].Token; got != tt.wantHost {
+				t.Errorf("hosts[%s] token = %q, want %q", host, got, tt.wantHost)
+			}
+			// Removing a token must not make the host unknown
+			if !saved.IsGitHubHost(host) {
+				t.Errorf("IsGitHubHost(%q) = false after SetHostToken", host)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+/*
+ * GitHubber - Git Branch Maintenance
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Default branch detection, merged branch cleanup and branch syncing
+ */
+
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RemoteDefaultBranch returns the default branch recorded for remote in
+// refs/remotes/<remote>/HEAD, which clone sets to the remote's HEAD
+func RemoteDefaultBranch(remote string) (string, error) {
+	output, err := RunCommand(fmt.Sprintf("git symbolic-ref --quiet --short %s", quoteArg("refs/remotes/"+remote+"/HEAD")))
+	if err != nil || output == "" {
+		return "", fmt.Errorf("default branch of %s is unknown", remote)
+	}
+	return strings.TrimPrefix(output, remote+"/"), nil
+}
+
+// SetRemoteDefaultBranch records branch as the default branch of remote
+func SetRemoteDefaultBranch(remote, branch string) error {
+	output, err := RunCommand(fmt.Sprintf("git remote set-head %s %s", quoteArg(remote), quoteArg(branch)))
+	if err != nil {
+		return fmt.Errorf("failed to set default branch of %s: %s", remote, output)
+	}
+	return nil
+}
+
+// MergedBranches returns the local branches fully merged into base, which
+// may be a remote-tracking branch of defaultBranch, excluding defaultBranch
+// and the checked out branch
+func MergedBranches(base, defaultBranch string) ([]string, error) {
+	output, err := RunCommand(fmt.Sprintf("git branch --merged %s --format=%s", quoteArg(base), quoteArg("%(HEAD)%(refname:short)")))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merged branches: %s", output)
+	}
+
+	var branches []string
+	for _, line := range strings.Split(output, "\n") {
+		if line == "" || strings.HasPrefix(line, "*") {
+			continue
+		}
+		name := strings.TrimSpace(line)
+		if name == base || name == defaultBranch {
+			continue
+		}
+		branches = append(branches, name)
+	}
+	return branches, nil
+}
+
+// DeleteMergedBranch deletes a local branch, refusing if it is not merged
+func DeleteMergedBranch(name string) error {
+	output, err := RunCommand(fmt.Sprintf("git branch -d %s", quoteArg(name)))
+	if err != nil {
+		return fmt.Errorf("failed to delete branch %s: %s", name, output)
+	}
+	return nil
+}
+
+// BranchCommit returns the commit a local branch points to
+func BranchCommit(name string) (string, error) {
+	hash, err := RunCommand(fmt.Sprintf("git rev-parse --verify --quiet %s", quoteArg("refs/heads/"+name)))
+	if err != nil || hash == "" {
+		return "", fmt.Errorf("branch %s does not exist", name)
+	}
+	return hash, nil
+}
+
+// DeleteLandedBranch deletes a local branch whose changes landed upstream
+// through a merged pull request with head commit head. Squash and rebase
+// merges leave the branch unmerged as far as git can tell, so the branch is
+// also deleted if it still points at head; local commits beyond that are
+// never discarded.
+func DeleteLandedBranch(name, head string) error {
+	if _, err := RunCommand(fmt.Sprintf("git branch -d %s", quoteArg(name))); err == nil {
+		return nil
+	}
+
+	commit, err := BranchCommit(name)
+	if err != nil {
+		return err
+	}
+	if commit != head {
+		return fmt.Errorf("branch %s has commits that are not part of the pull request; delete it manually if they are not needed", name)
+	}
+
+	output, err := RunCommand(fmt.Sprintf("git branch -D %s", quoteArg(name)))
+	if err != nil {
+		return fmt.Errorf("failed to delete branch %s: %s", name, output)
+	}
+	return nil
+}
+
+// FastForwardBranch updates a local branch to its counterpart on remote
+// without a merge commit. The branch does not need to be checked out.
+func FastForwardBranch(remote, branch string) error {
+	current, _ := CurrentBranch()
+
+	var command string
+	if current == branch {
+		command = fmt.Sprintf("git pull --ff-only %s %s", quoteArg(remote), quoteArg(branch))
+	} else {
+		command = fmt.Sprintf("git fetch %s %s", quoteArg(remote), quoteArg("refs/heads/"+branch+":refs/heads/"+branch))
+	}
+
+	output, err := RunCommand(command)
+	if err != nil {
+		return fmt.Errorf("failed to fast-forward %s from %s: %s", branch, remote, output)
+	}
+	return nil
+}
+
+// RebaseCurrentBranch rebases the checked out branch onto upstream. A
+// failed rebase is aborted, leaving the branch unchanged.
+func RebaseCurrentBranch(upstream string) error {
+	output, err := RunCommand(fmt.Sprintf("git rebase %s", quoteArg(upstream)))
+	if err != nil {
+		RunCommand("git rebase --abort")
+		return fmt.Errorf("rebase onto %s failed: %s", upstream, output)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+func TestInitWithBranch(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "git-tool-init-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tmpDir)
+
+	if err := InitWithBranch("trunk"); err != nil {
+		t.Fatalf("InitWithBranch() error = %v", err)
+	}
+	head, err := RunCommand("git symbolic-ref --short HEAD")
+	if err != nil || head != "trunk" {
+		t.Errorf("HEAD = %q, %v, want trunk", head, err)
+	}
+}
+
+func TestBranchMaintenance(t *testing.T) {
+	// Set up test repository
+	_, cleanup := setupTestRepo(t)
+	defer cleanup()
+	_, cleanupRemote := setupTestRemote(t, "origin")
+	defer cleanupRemote()
+
+	createTestFile(t, "test.txt", "initial")
+	createTestCommit(t, "Initial commit")
+	base, _ := CurrentBranch()
+	if _, err := PushCurrentBranch(); err != nil {
+		t.Fatalf("PushCurrentBranch() error = %v", err)
+	}
+
+	if _, err := RemoteDefaultBranch("origin"); err == nil {
+		t.Error("RemoteDefaultBranch() should fail before origin/HEAD is set")
+	}
+	if err := SetRemoteDefaultBranch("origin", base); err != nil {
+		t.Fatalf("SetRemoteDefaultBranch() error = %v", err)
+	}
+	if got, err := RemoteDefaultBranch("origin"); err != nil || got != base {
+		t.Errorf("RemoteDefaultBranch() = %q, %v, want %s", got, err, base)
+	}
+
+	// merged has no commits of its own; unmerged does
+	exec.Command("git", "branch", "merged").Run()
+	exec.Command("git", "checkout", "-q", "-b", "unmerged").Run()
+	createTestFile(t, "feature.txt", "feature")
+	createTestCommit(t, "Add feature")
+	exec.Command("git", "checkout", "-q", base).Run()
+
+	branches, err := MergedBranches(base, base)
+	if err != nil {
+		t.Fatalf("MergedBranches() error = %v", err)
+	}
+	if !reflect.DeepEqual(branches, []string{"merged"}) {
+		t.Errorf("MergedBranches() = %v, want [merged]", branches)
+	}
+
+	// Only the default branch itself is hidden, not branches named like
+	// the end of its remote-tracking branch
+	if _, err := RunCommand("git push -q origin HEAD:release/1.0"); err != nil {
+		t.Fatalf("Failed to push: %v", err)
+	}
+	exec.Command("git", "branch", "1.0").Run()
+	branches, err = MergedBranches("origin/release/1.0", "release/1.0")
+	if err != nil {
+		t.Fatalf("MergedBranches() error = %v", err)
+	}
+	if !reflect.DeepEqual(branches, []string{"1.0", "merged"}) {
+		t.Errorf("MergedBranches() = %v, want [1.0 merged]", branches)
+	}
+	if err := DeleteMergedBranch("unmerged"); err == nil {
+		t.Error("DeleteMergedBranch() should refuse unmerged branches")
+	}
+	if err := DeleteMergedBranch("merged"); err != nil {
+		t.Errorf("DeleteMergedBranch() error = %v", err)
+	}
+
+	// Advance origin from another branch, then fast-forward base while on unmerged
+	exec.Command("git", "checkout", "-q", "unmerged").Run()
+	if _, err := RunCommand("git push -q origin unmerged:" + base); err != nil {
+		t.Fatalf("Failed to advance origin: %v", err)
+	}
+	exec.Command("git", "reset", "-q", "--hard", "HEAD~1").Run()
+	if err := FastForwardBranch("origin", base); err != nil {
+		t.Fatalf("FastForwardBranch() error = %v", err)
+	}
+	baseHead, _ := RunCommand("git rev-parse " + base)
+	remoteHead, _ := RunCommand("git rev-parse origin/" + base)
+	if baseHead != remoteHead {
+		t.Errorf("%s = %s, want origin/%s = %s", base, baseHead, base, remoteHead)
+	}
+
+	if err := RebaseCurrentBranch(base); err != nil {
+		t.Fatalf("RebaseCurrentBranch() error = %v", err)
+	}
+	assertFileExists(t, "feature.txt")
+}
+
+func TestDeleteLandedBranch(t *testing.T) {
+	_, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	createTestFile(t, "test.txt", "initial")
+	createTestCommit(t, "Initial commit")
+	base, _ := CurrentBranch()
+
+	// Simulate a squash merge: the branch's commit never reaches base
+	exec.Command("git", "checkout", "-q", "-b", "squashed").Run()
+	createTestFile(t, "feature.txt", "feature")
+	createTestCommit(t, "Add feature")
+	head, err := BranchCommit("squashed")
+	if err != nil {
+		t.Fatalf("BranchCommit() error = %v", err)
+	}
+	exec.Command("git", "checkout", "-q", base).Run()
+
+	// A branch that moved on after the pull request was merged is kept
+	exec.Command("git", "branch", "moved", "squashed").Run()
+	exec.Command("git", "checkout", "-q", "moved").Run()
+	createTestFile(t, "more.txt", "more")
+	createTestCommit(t, "More work")
+	exec.Command("git", "checkout", "-q", base).Run()
+
+	if err := DeleteLandedBranch("moved", head); err == nil {
+		t.Error("DeleteLandedBranch() should keep branches with unmerged local commits")
+	}
+	if err := DeleteLandedBranch("squashed", head); err != nil {
+		t.Errorf("DeleteLandedBranch() error = %v", err)
+	}
+	if _, err := BranchCommit("squashed"); err == nil {
+		t.Error("branch squashed still exists")
+	}
+	if _, err := BranchCommit("moved"); err != nil {
+		t.Errorf("branch moved was deleted: %v", err)
+	}
+}
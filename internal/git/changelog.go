@@ -0,0 +1,314 @@
+/*
+ * GitHubber - Changelog Generation
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Builds Markdown and JSON changelogs from commit history
+ */
+
+package git
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ChangelogGrouping selects how changelog entries are grouped into sections
+type ChangelogGrouping string
+
+const (
+	GroupByType  ChangelogGrouping = "type"
+	GroupByLabel ChangelogGrouping = "label"
+)
+
+// ChangelogEntry is a single commit as it appears in a changelog
+type ChangelogEntry struct {
+	Hash        string    `json:"hash"`
+	Type        string    `json:"type,omitempty"`
+	Scope       string    `json:"scope,omitempty"`
+	Description string    `json:"description"`
+	Breaking    bool      `json:"breaking,omitempty"`
+	PullRequest int       `json:"pull_request,omitempty"`
+	Issues      []int     `json:"issues,omitempty"` // Closed with a keyword such as "Fixes #12"
+	Refs        []int     `json:"refs,omitempty"`   // Referenced without closing
+	Labels      []string  `json:"labels,omitempty"`
+	Author      string    `json:"author"`
+	Date        time.Time `json:"date"`
+}
+
+// ChangelogSection is a titled group of changelog entries
+type ChangelogSection struct {
+	Title   string           `json:"title"`
+	Entries []ChangelogEntry `json:"entries"`
+}
+
+// Changelog describes the changes between two revisions
+type Changelog struct {
+	Title    string             `json:"title"`
+	From     string             `json:"from,omitempty"`
+	To       string             `json:"to"`
+	Date     time.Time          `json:"date"`
+	RepoURL  string             `json:"repo_url,omitempty"`
+	Breaking []ChangelogEntry   `json:"breaking,omitempty"`
+	Sections []ChangelogSection `json:"sections"`
+}
+
+var (
+	pullRequestSuffix = regexp.MustCompile(`\s*\(#(\d+)\)$`)
+	mergePullRequest  = regexp.MustCompile(`^Merge pull request #(\d+)`)
+	issueReference    = regexp.MustCompile(`(?:^|[^\w&])#(\d+)\b`)
+	// closingReference matches GitHub's keywords for closing issues
+	closingReference = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+#(\d+)\b`)
+)
+
+// DefaultChangelogRange returns the range between the two most recent
+// version tags. With a single tag the range covers the whole history up to
+// it, and without tags it covers everything up to HEAD.
+func DefaultChangelogRange() (from, to string, err error) {
+	tags, err := ListTags()
+	if err != nil {
+		return "", "", err
+	}
+
+	type versionTag struct {
+		name    string
+		version Version
+	}
+	var versions []versionTag
+	for _, tag := range tags {
+		if version, err := ParseVersion(tag.Name); err == nil {
+			versions = append(versions, versionTag{tag.Name, version})
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].version.Compare(versions[j].version) > 0
+	})
+
+	switch len(versions) {
+	case 0:
+		return "", "HEAD", nil
+	case 1:
+		return "", versions[0].name, nil
+	default:
+		return versions[1].name, versions[0].name, nil
+	}
+}
+
+// ChangelogEntries returns the changelog entries for the commits between from and to
+func ChangelogEntries(from, to string) ([]ChangelogEntry, error) {
+	commits, err := CommitsInRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]ChangelogEntry, 0, len(commits))
+	for _, commit := range commits {
+		entries = append(entries, newChangelogEntry(commit))
+	}
+	return entries, nil
+}
+
+// newChangelogEntry converts a commit into a changelog entry, extracting
+// Conventional Commits metadata and pull request and issue references
+func newChangelogEntry(commit CommitDetail) ChangelogEntry {
+	subject := commit.Subject
+	body := commit.Body
+
+	entry := ChangelogEntry{
+		Hash:   commit.Hash,
+		Author: commit.Author,
+		Date:   commit.Date,
+	}
+
+	// GitHub merge commits carry the PR title in the body
+	if match := mergePullRequest.FindStringSubmatch(subject); match != nil {
+		entry.PullRequest, _ = strconv.Atoi(match[1])
+		if title, rest, _ := strings.Cut(body, "\n"); title != "" {
+			subject, body = title, rest
+		}
+	}
+	if match := pullRequestSuffix.FindStringSubmatch(subject); match != nil {
+		entry.PullRequest, _ = strconv.Atoi(match[1])
+		subject = strings.TrimSuffix(subject, match[0])
+	}
+
+	parsed, ok := ParseConventionalCommit(subject, body)
+	entry.Description = parsed.Description
+	entry.Breaking = parsed.Breaking
+	if ok {
+		entry.Type = parsed.Type
+		entry.Scope = parsed.Scope
+	}
+
+	// Only keywords like "Fixes #12" close issues; other references, such
+	// as a "Refs: #12" footer, are listed separately
+	text := subject + "\n" + body
+	seen := map[int]bool{entry.PullRequest: true}
+	for _, match := range closingReference.FindAllStringSubmatch(text, -1) {
+		number, _ := strconv.Atoi(match[1])
+		if !seen[number] {
+			seen[number] = true
+			entry.Issues = append(entry.Issues, number)
+		}
+	}
+	for _, match := range issueReference.FindAllStringSubmatch(text, -1) {
+		number, _ := strconv.Atoi(match[1])
+		if !seen[number] {
+			seen[number] = true
+			entry.Refs = append(entry.Refs, number)
+		}
+	}
+
+	return entry
+}
+
+// NewChangelog groups entries into sections. With GroupByLabel each entry
+// is placed under its first label; entries without labels and commits that
+// do not follow Conventional Commits end up in "Other Changes".
+func NewChangelog(title, from, to string, entries []ChangelogEntry, grouping ChangelogGrouping) *Changelog {
+	changelog := &Changelog{
+		Title: title,
+		From:  from,
+		To:    to,
+		Date:  time.Now(),
+	}
+
+	sections := make(map[string][]ChangelogEntry)
+	var order []string
+	for _, entry := range entries {
+		if entry.Breaking {
+			changelog.Breaking = append(changelog.Breaking, entry)
+		}
+
+		section := "Other Changes"
+		switch {
+		case grouping == GroupByLabel && len(entry.Labels) > 0:
+			section = entry.Labels[0]
+		case grouping == GroupByType && entry.Type != "":
+			section = CommitTypeTitle(entry.Type)
+		}
+		if _, ok := sections[section]; !ok {
+			order = append(order, section)
+		}
+		sections[section] = append(sections[section], entry)
+	}
+
+	if grouping == GroupByType {
+		order = sortedSectionTitles(order)
+	} else {
+		sort.SliceStable(order, func(i, j int) bool {
+			// Keep the catch-all section last
+			return order[j] == "Other Changes" && order[i] != "Other Changes"
+		})
+	}
+
+	for _, title := range order {
+		changelog.Sections = append(changelog.Sections, ChangelogSection{Title: title, Entries: sections[title]})
+	}
+	return changelog
+}
+
+// Markdown renders the changelog as a Markdown release section
+func (c *Changelog) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s (%s)\n", c.Title, c.Date.Format("2006-01-02"))
+
+	if len(c.Breaking) > 0 {
+		b.WriteString("\n### ⚠ BREAKING CHANGES\n\n")
+		for _, entry := range c.Breaking {
+			b.WriteString(c.markdownEntry(entry))
+		}
+	}
+	for _, section := range c.Sections {
+		fmt.Fprintf(&b, "\n### %s\n\n", section.Title)
+		for _, entry := range section.Entries {
+			b.WriteString(c.markdownEntry(entry))
+		}
+	}
+	return b.String()
+}
+
+// markdownEntry renders a single changelog line with commit, pull request
+// and closed and referenced issue links
+func (c *Changelog) markdownEntry(entry ChangelogEntry) string {
+	line := "- "
+	if entry.Scope != "" {
+		line += fmt.Sprintf("**%s:** ", entry.Scope)
+	}
+	line += entry.Description
+
+	if entry.PullRequest != 0 {
+		line += " " + c.link(fmt.Sprintf("#%d", entry.PullRequest), fmt.Sprintf("pull/%d", entry.PullRequest))
+	}
+	short := entry.Hash
+	if len(short) > 7 {
+		short = short[:7]
+	}
+	line += " (" + c.link(short, "commit/"+entry.Hash) + ")"
+
+	if len(entry.Issues) > 0 {
+		line += ", closes " + c.issueLinks(entry.Issues)
+	}
+	if len(entry.Refs) > 0 {
+		line += ", refs " + c.issueLinks(entry.Refs)
+	}
+	return line + "\n"
+}
+
+// issueLinks renders a comma-separated list of issue links
+func (c *Changelog) issueLinks(numbers []int) string {
+	links := make([]string, len(numbers))
+	for i, number := range numbers {
+		links[i] = c.link(fmt.Sprintf("#%d", number), fmt.Sprintf("issues/%d", number))
+	}
+	return strings.Join(links, ", ")
+}
+
+// link renders text as a Markdown link into the repository when its URL is known
+func (c *Changelog) link(text, path string) string {
+	if c.RepoURL == "" {
+		return text
+	}
+	return fmt.Sprintf("[%s](%s/%s)", text, strings.TrimSuffix(c.RepoURL, "/"), path)
+}
+
+// JSON renders the changelog as indented JSON
+func (c *Changelog) JSON() (string, error) {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal changelog: %w", err)
+	}
+	return string(data), nil
+}
+
+// PrependChangelog inserts a rendered release section at the top of the
+// changelog file at path, below its top-level heading. The file is created
+// when it does not exist.
+func PrependChangelog(path, section string) error {
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	header := "# Changelog\n\n"
+	rest := string(existing)
+	if strings.HasPrefix(rest, "# ") {
+		heading, remainder, _ := strings.Cut(rest, "\n")
+		header = heading + "\n\n"
+		rest = strings.TrimLeft(remainder, "\n")
+	}
+
+	content := header + strings.TrimRight(section, "\n") + "\n"
+	if rest != "" {
+		content += "\n" + rest
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
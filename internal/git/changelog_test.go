@@ -0,0 +1,151 @@
+package git
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewChangelogEntry(t *testing.T) {
+	tests := []struct {
+		name   string
+		commit CommitDetail
+		want   ChangelogEntry
+	}{
+		{
+			name:   "squash merge with issue",
+			commit: CommitDetail{Hash: "abc", Subject: "feat(cli): add changelog (#42)", Body: "Fixes #7 and refs #8"},
+			want:   ChangelogEntry{Hash: "abc", Type: "feat", Scope: "cli", Description: "add changelog", PullRequest: 42, Issues: []int{7}, Refs: []int{8}},
+		},
+		{
+			name:   "refs footer",
+			commit: CommitDetail{Hash: "fed", Subject: "fix: retry uploads", Body: "Uploads failed on slow links.\n\nCloses: #11\nRefs: #10, #12"},
+			want:   ChangelogEntry{Hash: "fed", Type: "fix", Description: "retry uploads", Issues: []int{11}, Refs: []int{10, 12}},
+		},
+		{
+			name:   "merge commit",
+			commit: CommitDetail{Hash: "def", Subject: "Merge pull request #9 from user/branch", Body: "fix: handle empty input"},
+			want:   ChangelogEntry{Hash: "def", Type: "fix", Description: "handle empty input", PullRequest: 9},
+		},
+		{
+			name:   "plain commit",
+			commit: CommitDetail{Hash: "123", Subject: "Update README"},
+			want:   ChangelogEntry{Hash: "123", Description: "Update README"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newChangelogEntry(tt.commit); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newChangelogEntry() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChangelogRendering(t *testing.T) {
+	entries := []ChangelogEntry{
+		{Hash: "1111111aaaa", Type: "fix", Description: "fix crash", PullRequest: 3, Labels: []string{"bug"}},
+		{Hash: "2222222bbbb", Type: "feat", Scope: "api", Description: "add endpoint", Breaking: true, Issues: []int{5}, Refs: []int{6}, Labels: []string{"enhancement"}},
+		{Hash: "3333333cccc", Description: "Tidy up"},
+	}
+
+	changelog := NewChangelog("v2.0.0", "v1.0.0", "v2.0.0", entries, GroupByType)
+	changelog.RepoURL = "https://github.com/owner/repo"
+
+	var titles []string
+	for _, section := range changelog.Sections {
+		titles = append(titles, section.Title)
+	}
+	if !reflect.DeepEqual(titles, []string{"Features", "Bug Fixes", "Other Changes"}) {
+		t.Errorf("sections = %v", titles)
+	}
+
+	markdown := changelog.Markdown()
+	for _, want := range []string{
+		"## v2.0.0 (",
+		"### ⚠ BREAKING CHANGES",
+		"- **api:** add endpoint ([2222222](https://github.com/owner/repo/commit/2222222bbbb)), closes [#5](https://github.com/owner/repo/issues/5), refs [#6](https://github.com/owner/repo/issues/6)",
+		"- fix crash [#3](https://github.com/owner/repo/pull/3) ([1111111](https://github.com/owner/repo/commit/1111111aaaa))",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Markdown() = %s\nwant it to contain %q", markdown, want)
+		}
+	}
+
+	byLabel := NewChangelog("v2.0.0", "v1.0.0", "v2.0.0", entries, GroupByLabel)
+	titles = nil
+	for _, section := range byLabel.Sections {
+		titles = append(titles, section.Title)
+	}
+	if !reflect.DeepEqual(titles, []string{"bug", "enhancement", "Other Changes"}) {
+		t.Errorf("label sections = %v", titles)
+	}
+
+	output, err := byLabel.JSON()
+	if err != nil {
+		t.Fatalf("JSON() error = %v", err)
+	}
+	var decoded Changelog
+	if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+		t.Fatalf("JSON() produced invalid JSON: %v", err)
+	}
+	if decoded.Title != "v2.0.0" || len(decoded.Sections) != 3 {
+		t.Errorf("decoded changelog = %+v", decoded)
+	}
+}
+
+func TestDefaultChangelogRange(t *testing.T) {
+	// Set up test repository
+	_, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	createTestFile(t, "a.txt", "a")
+	createTestCommit(t, "chore: initial commit")
+
+	from, to, err := DefaultChangelogRange()
+	if err != nil || from != "" || to != "HEAD" {
+		t.Errorf("DefaultChangelogRange() = %q, %q, %v; want \"\", HEAD", from, to, err)
+	}
+
+	CreateTag("v0.9.0", "Release v0.9.0")
+	createTestFile(t, "b.txt", "b")
+	createTestCommit(t, "feat: second")
+	CreateTag("v0.10.0", "Release v0.10.0")
+	CreateTag("not-a-version", "ignored")
+
+	from, to, err = DefaultChangelogRange()
+	if err != nil || from != "v0.9.0" || to != "v0.10.0" {
+		t.Errorf("DefaultChangelogRange() = %q, %q, %v; want v0.9.0, v0.10.0", from, to, err)
+	}
+
+	entries, err := ChangelogEntries(from, to)
+	if err != nil {
+		t.Fatalf("ChangelogEntries() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Description != "second" {
+		t.Errorf("ChangelogEntries() = %+v, want the single feat commit", entries)
+	}
+}
+
+func TestPrependChangelog(t *testing.T) {
+	// Set up test repository
+	_, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	if err := PrependChangelog("CHANGELOG.md", "## v1.0.0\n\n- first\n"); err != nil {
+		t.Fatalf("PrependChangelog() error = %v", err)
+	}
+	assertFileContent(t, "CHANGELOG.md", "# Changelog\n\n## v1.0.0\n\n- first\n")
+
+	if err := PrependChangelog("CHANGELOG.md", "## v1.1.0\n\n- second\n"); err != nil {
+		t.Fatalf("PrependChangelog() error = %v", err)
+	}
+	assertFileContent(t, "CHANGELOG.md", "# Changelog\n\n## v1.1.0\n\n- second\n\n## v1.0.0\n\n- first\n")
+
+	if _, err := os.Stat("CHANGELOG.md"); err != nil {
+		t.Errorf("CHANGELOG.md should exist: %v", err)
+	}
+}
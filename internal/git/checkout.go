@@ -0,0 +1,141 @@
+/*
+ * GitHubber - Git Pull Request Checkout
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Fetching pull requests into local branches and keeping them up to date
+ */
+
+package git
+
+import (
+	"fmt"
+)
+
+// PullRequestCheckout describes where to fetch a pull request from and the
+// local branch to check it out as
+type PullRequestCheckout struct {
+	Number int
+	Branch string // Local branch to create or update
+	Remote string // Remote to fetch from
+	// RemoteBranch is the pull request's head branch on Remote, which the
+	// local branch tracks so pushes update the pull request. When empty the
+	// read-only refs/pull/<Number>/head of Remote is fetched and tracked.
+	RemoteBranch string
+	// Force resets an existing branch whose history no longer leads to the
+	// pull request head, e.g. after a force push
+	Force bool
+}
+
+// PullRequestCheckoutResult describes a checked out pull request
+type PullRequestCheckoutResult struct {
+	Branch  string
+	Created bool
+	From    string // Previous tip of an existing branch
+	To      string // Tip after the checkout
+}
+
+// UpToDate reports whether an existing branch was already at the pull
+// request head
+func (r *PullRequestCheckoutResult) UpToDate() bool {
+	return !r.Created && r.From == r.To
+}
+
+// BranchDivergedError is returned when an existing branch has commits that
+// are not in the pull request, so it cannot be fast-forwarded
+type BranchDivergedError struct {
+	Branch string
+	Ref    string
+}
+
+func (e *BranchDivergedError) Error() string {
+	return fmt.Sprintf("branch %s has diverged from %s", e.Branch, e.Ref)
+}
+
+// MergeRef returns the ref a pull request checkout tracks on its remote
+func (opts PullRequestCheckout) MergeRef() string {
+	if opts.RemoteBranch != "" {
+		return "refs/heads/" + opts.RemoteBranch
+	}
+	return fmt.Sprintf("refs/pull/%d/head", opts.Number)
+}
+
+// CheckoutPullRequest fetches a pull request and checks it out as
+// opts.Branch. A new branch is created at the pull request head; an
+// existing one is fast-forwarded to it. Either way the branch is set to
+// track opts.MergeRef() on opts.Remote, so later pulls bring in new commits.
+func CheckoutPullRequest(opts PullRequestCheckout) (*PullRequestCheckoutResult, error) {
+	mergeRef := opts.MergeRef()
+	fetched := "FETCH_HEAD"
+	refspec := mergeRef
+	if opts.RemoteBranch != "" {
+		fetched = "refs/remotes/" + opts.Remote + "/" + opts.RemoteBranch
+		refspec = "+" + mergeRef + ":" + fetched
+	}
+	if output, err := RunCommand(fmt.Sprintf("git fetch %s %s", quoteArg(opts.Remote), quoteArg(refspec))); err != nil {
+		return nil, fmt.Errorf("failed to fetch pull request #%d: %s", opts.Number, output)
+	}
+	head, err := RunCommand(fmt.Sprintf("git rev-parse --verify --quiet %s", quoteArg(fetched+"^{commit}")))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve pull request #%d", opts.Number)
+	}
+
+	result := &PullRequestCheckoutResult{Branch: opts.Branch, To: head}
+	current, _ := CurrentBranch()
+	if result.From, err = BranchCommit(opts.Branch); err != nil {
+		result.Created = true
+		if output, err := RunCommand(fmt.Sprintf("git checkout -b %s %s", quoteArg(opts.Branch), quoteArg(head))); err != nil {
+			return nil, fmt.Errorf("failed to create branch %s: %s", opts.Branch, output)
+		}
+	} else if err := moveBranch(opts, current, result.From, head); err != nil {
+		return nil, err
+	}
+
+	if err := setUpstream(opts.Branch, opts.Remote, mergeRef); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// moveBranch updates an existing branch from its tip from to the pull
+// request head and checks it out
+func moveBranch(opts PullRequestCheckout, current, from, head string) error {
+	fastForward := from == head
+	if !fastForward {
+		_, err := RunCommand(fmt.Sprintf("git merge-base --is-ancestor %s %s", quoteArg(from), quoteArg(head)))
+		fastForward = err == nil
+	}
+	if !fastForward && !opts.Force {
+		return &BranchDivergedError{Branch: opts.Branch, Ref: fmt.Sprintf("pull request #%d", opts.Number)}
+	}
+
+	if current == opts.Branch {
+		if from == head {
+			return nil
+		}
+		// --keep refuses to overwrite uncommitted changes to affected files
+		output, err := RunCommand(fmt.Sprintf("git reset --keep %s", quoteArg(head)))
+		if err != nil {
+			return fmt.Errorf("failed to update branch %s: %s", opts.Branch, output)
+		}
+		return nil
+	}
+
+	if output, err := RunCommand(fmt.Sprintf("git branch --force %s %s", quoteArg(opts.Branch), quoteArg(head))); err != nil {
+		return fmt.Errorf("failed to update branch %s: %s", opts.Branch, output)
+	}
+	if output, err := RunCommand(fmt.Sprintf("git checkout %s", quoteArg(opts.Branch))); err != nil {
+		return fmt.Errorf("failed to switch to branch %s: %s", opts.Branch, output)
+	}
+	return nil
+}
+
+// setUpstream makes branch track ref on remote. Unlike git branch
+// --set-upstream-to, ref does not need a remote-tracking branch.
+func setUpstream(branch, remote, ref string) error {
+	for key, value := range map[string]string{"remote": remote, "merge": ref} {
+		output, err := RunCommand(fmt.Sprintf("git config %s %s", quoteArg("branch."+branch+"."+key), quoteArg(value)))
+		if err != nil {
+			return fmt.Errorf("failed to set upstream of %s: %s", branch, output)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package git
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+)
+
+func TestCheckoutPullRequest(t *testing.T) {
+	_, cleanup := setupTestRepo(t)
+	defer cleanup()
+	_, cleanupRemote := setupTestRemote(t, "origin")
+	defer cleanupRemote()
+
+	createTestFile(t, "test.txt", "initial")
+	createTestCommit(t, "Initial commit")
+	base, _ := CurrentBranch()
+	if _, err := PushCurrentBranch(); err != nil {
+		t.Fatalf("PushCurrentBranch() error = %v", err)
+	}
+
+	// Publish a pull request the way GitHub does, as a branch and refs/pull/7/head
+	exec.Command("git", "checkout", "-q", "-b", "author").Run()
+	createTestFile(t, "feature.txt", "feature")
+	createTestCommit(t, "Add feature")
+	publish := func() string {
+		t.Helper()
+		if _, err := RunCommand("git push -q --force origin HEAD:refs/pull/7/head HEAD:refs/heads/feature"); err != nil {
+			t.Fatalf("Failed to publish pull request: %v", err)
+		}
+		head, _ := RunCommand("git rev-parse HEAD")
+		return head
+	}
+	head := publish()
+	exec.Command("git", "checkout", "-q", base).Run()
+
+	opts := PullRequestCheckout{Number: 7, Branch: "pr-7", Remote: "origin"}
+	result, err := CheckoutPullRequest(opts)
+	if err != nil {
+		t.Fatalf("CheckoutPullRequest() error = %v", err)
+	}
+	if !result.Created || result.To != head {
+		t.Errorf("CheckoutPullRequest() = %+v, want new branch at %s", result, head)
+	}
+	assertGitBranch(t, "pr-7")
+	if remote, ref, ok := Upstream("pr-7"); !ok || remote != "origin" || ref != "refs/pull/7/head" {
+		t.Errorf("Upstream() = %s %s %v, want origin refs/pull/7/head", remote, ref, ok)
+	}
+	if _, err := BranchPushOptions("pr-7"); err == nil {
+		t.Error("BranchPushOptions() should refuse to push to refs/pull")
+	}
+
+	// A repeat checkout is up to date, then fast-forwards to new commits
+	if result, err = CheckoutPullRequest(opts); err != nil || !result.UpToDate() {
+		t.Errorf("repeat CheckoutPullRequest() = %+v, %v, want up to date", result, err)
+	}
+	exec.Command("git", "checkout", "-q", "author").Run()
+	createTestFile(t, "more.txt", "more")
+	createTestCommit(t, "More work")
+	head = publish()
+	exec.Command("git", "checkout", "-q", base).Run()
+	if result, err = CheckoutPullRequest(opts); err != nil || result.To != head || result.UpToDate() {
+		t.Errorf("CheckoutPullRequest() after new commits = %+v, %v, want %s", result, err, head)
+	}
+	assertGitBranch(t, "pr-7")
+
+	// A force-pushed pull request needs Force
+	exec.Command("git", "checkout", "-q", "author").Run()
+	exec.Command("git", "commit", "-q", "--amend", "-m", "Rewritten").Run()
+	head = publish()
+	exec.Command("git", "checkout", "-q", "pr-7").Run()
+	var diverged *BranchDivergedError
+	if _, err = CheckoutPullRequest(opts); !errors.As(err, &diverged) {
+		t.Fatalf("CheckoutPullRequest() after force push error = %v, want *BranchDivergedError", err)
+	}
+	opts.Force = true
+	if result, err = CheckoutPullRequest(opts); err != nil || result.To != head {
+		t.Errorf("forced CheckoutPullRequest() = %+v, %v, want %s", result, err, head)
+	}
+	if current, _ := RunCommand("git rev-parse HEAD"); current != head {
+		t.Errorf("HEAD = %s, want %s", current, head)
+	}
+
+	// Checking out the head branch tracks it so pushes update the pull request
+	result, err = CheckoutPullRequest(PullRequestCheckout{Number: 7, Branch: "feature", Remote: "origin", RemoteBranch: "feature"})
+	if err != nil || !result.Created {
+		t.Fatalf("CheckoutPullRequest() of head branch = %+v, %v", result, err)
+	}
+	push, err := BranchPushOptions("feature")
+	if err != nil || push.Remote != "origin" || push.RemoteRef != "feature" {
+		t.Errorf("BranchPushOptions() = %+v, %v, want origin/feature", push, err)
+	}
+}
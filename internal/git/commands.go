@@ -17,6 +17,15 @@ func Init() error {
 	return err
 }
 
+// InitWithBranch initializes a repository whose initial branch is branch
+func InitWithBranch(branch string) error {
+	if branch == "" {
+		return Init()
+	}
+	_, err := RunCommand(fmt.Sprintf("git init --initial-branch=%s", quoteArg(branch)))
+	return err
+}
+
 func Clone(url string) error {
 	_, err := RunCommand(fmt.Sprintf("git clone %s", url))
 	return err
@@ -61,8 +70,17 @@ func AddFiles(files ...string) error {
 	return err
 }
 
+// Commit creates a commit with message, which may span several lines. The
+// message is passed through a file so it needs no shell quoting. The commit
+// is signed when signing has been enabled with ConfigureSigning.
 func Commit(message string) error {
-	_, err := RunCommand(fmt.Sprintf("git commit -m \"%s\"", message))
+	path, cleanup, err := writeMessageFile(message)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	_, err = RunCommand(fmt.Sprintf("%s commit%s --cleanup=whitespace -F %s", gitCommand(), signFlag(), quoteArg(path)))
 	return err
 }
 
@@ -93,8 +111,7 @@ func Diff(file string) (string, error) {
 
 // Stash Operations
 func StashSave(message string) error {
-	_, err := RunCommand(fmt.Sprintf("git stash push -m \"%s\"", message))
-	return err
+	return StashPush(StashOptions{Message: message})
 }
 
 func StashPop() error {
@@ -108,15 +125,10 @@ func StashList() (string, error) {
 
 // Tag Operations
 func CreateTag(name, message string) error {
-	_, err := RunCommand(fmt.Sprintf("git tag -a %s -m \"%s\"", name, message))
-	return err
+	return CreateTagWithOptions(name, TagOptions{Message: message, Annotated: true})
 }
 
 func DeleteTag(name string) error {
 	_, err := RunCommand(fmt.Sprintf("git tag -d %s", name))
 	return err
 }
-
-func ListTags() (string, error) {
-	return RunCommand("git tag")
-}
@@ -246,7 +246,7 @@ func TestTagOperations(t *testing.T) {
 	if err != nil {
 		t.Errorf("ListTags() error = %v", err)
 	}
-	if !strings.Contains(tags, "v1.0.0") {
+	if len(tags) != 1 || tags[0].Name != "v1.0.0" {
 		t.Errorf("ListTags() = %v, want v1.0.0", tags)
 	}
 
@@ -261,7 +261,7 @@ func TestTagOperations(t *testing.T) {
 	if err != nil {
 		t.Errorf("ListTags() error = %v", err)
 	}
-	if strings.Contains(tags, "v1.0.0") {
+	if len(tags) != 0 {
 		t.Errorf("ListTags() = %v, tag should be deleted", tags)
 	}
 }
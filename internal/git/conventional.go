@@ -0,0 +1,279 @@
+/*
+ * GitHubber - Conventional Commits
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Parsing of Conventional Commits messages and commit type metadata
+ */
+
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// ConventionalCommit is a commit message split into its Conventional Commits parts
+type ConventionalCommit struct {
+	Type         string
+	Scope        string
+	Description  string
+	Body         string
+	Breaking     bool
+	BreakingNote string
+}
+
+var conventionalHeader = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^()]*)\))?(!)?: (.+)$`)
+
+// commitTypeTitles maps commit types to the section titles used in
+// release notes and changelogs, in display order
+var commitTypeTitles = []struct {
+	Type  string
+	Title string
+}{
+	{"feat", "Features"},
+	{"fix", "Bug Fixes"},
+	{"perf", "Performance Improvements"},
+	{"refactor", "Code Refactoring"},
+	{"revert", "Reverts"},
+	{"docs", "Documentation"},
+	{"style", "Styles"},
+	{"test", "Tests"},
+	{"build", "Build System"},
+	{"ci", "Continuous Integration"},
+	{"chore", "Chores"},
+}
+
+// ConventionalTypes returns the commit types known to GitHubber in display order
+func ConventionalTypes() []string {
+	types := make([]string, len(commitTypeTitles))
+	for i, t := range commitTypeTitles {
+		types[i] = t.Type
+	}
+	return types
+}
+
+// CommitTypeTitle returns the section title for a commit type
+func CommitTypeTitle(commitType string) string {
+	for _, t := range commitTypeTitles {
+		if t.Type == commitType {
+			return t.Title
+		}
+	}
+	return "Other Changes"
+}
+
+// ParseConventionalCommit parses a commit subject and body. The boolean
+// result is false when the subject does not follow Conventional Commits.
+func ParseConventionalCommit(subject, body string) (ConventionalCommit, bool) {
+	match := conventionalHeader.FindStringSubmatch(strings.TrimSpace(subject))
+	if match == nil {
+		return ConventionalCommit{Description: subject, Body: body}, false
+	}
+
+	commit := ConventionalCommit{
+		Type:        strings.ToLower(match[1]),
+		Scope:       match[2],
+		Breaking:    match[3] == "!",
+		Description: match[4],
+		Body:        strings.TrimSpace(body),
+	}
+
+	for _, line := range strings.Split(body, "\n") {
+		for _, token := range []string{"BREAKING CHANGE:", "BREAKING-CHANGE:"} {
+			if note, ok := strings.CutPrefix(line, token); ok {
+				commit.Breaking = true
+				commit.BreakingNote = strings.TrimSpace(note)
+			}
+		}
+	}
+	if commit.Breaking && commit.BreakingNote == "" {
+		commit.BreakingNote = commit.Description
+	}
+
+	return commit, true
+}
+
+// CommitMessage is a Conventional Commits message assembled by the commit composer
+type CommitMessage struct {
+	Type           string
+	Scope          string
+	Subject        string
+	Body           string
+	Breaking       bool
+	BreakingChange string   // Description for the BREAKING CHANGE footer
+	Closes         []string // Issues closed by the commit, such as "#12"
+	References     []string // Related issues, such as "owner/repo#7"
+	// header is the first line of a free-form message as written, see
+	// ParseCommitMessage
+	header string
+}
+
+// CommitRules configures ValidateCommitMessage
+type CommitRules struct {
+	MaxSubjectLength int      // Maximum header length, zero for no limit
+	AllowedTypes     []string // Empty allows the Conventional Commits types
+	AllowedScopes    []string // Empty allows any scope
+	RequireScope     bool
+	CheckImperative  bool
+}
+
+// Header returns the first line of the message, e.g. "feat(cli)!: add composer"
+func (m CommitMessage) Header() string {
+	if m.header != "" {
+		return m.header
+	}
+	header := m.Type
+	if m.Scope != "" {
+		header += "(" + m.Scope + ")"
+	}
+	if m.Breaking || m.BreakingChange != "" {
+		header += "!"
+	}
+	return header + ": " + m.Subject
+}
+
+// String renders the full commit message with body and footers
+func (m CommitMessage) String() string {
+	parts := []string{m.Header()}
+	if body := strings.TrimSpace(m.Body); body != "" {
+		parts = append(parts, body)
+	}
+
+	var footers []string
+	if m.BreakingChange != "" {
+		footers = append(footers, "BREAKING CHANGE: "+m.BreakingChange)
+	}
+	for _, ref := range m.Closes {
+		footers = append(footers, "Closes: "+ref)
+	}
+	for _, ref := range m.References {
+		footers = append(footers, "Refs: "+ref)
+	}
+	if len(footers) > 0 {
+		parts = append(parts, strings.Join(footers, "\n"))
+	}
+
+	return strings.Join(parts, "\n\n") + "\n"
+}
+
+// ParseCommitMessage splits a free-form commit message into the parts
+// ValidateCommitMessage checks. Free-form messages need not follow
+// Conventional Commits, so their type and scope are not validated and the
+// header is measured as written. "Closes:" and "Refs:" footers are moved
+// from the body into Closes and References.
+func ParseCommitMessage(message string) CommitMessage {
+	subject, body, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	commit, ok := ParseConventionalCommit(subject, body)
+	m := CommitMessage{Subject: commit.Description, header: strings.TrimSpace(subject)}
+	if ok {
+		m.Type = commit.Type
+		m.Scope = commit.Scope
+	}
+
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(body), "\n") {
+		match := issueFooterPattern.FindStringSubmatch(line)
+		if match == nil {
+			lines = append(lines, line)
+			continue
+		}
+		refs := strings.Fields(strings.ReplaceAll(match[2], ",", " "))
+		if strings.EqualFold(match[1], "closes") {
+			m.Closes = append(m.Closes, refs...)
+		} else {
+			m.References = append(m.References, refs...)
+		}
+	}
+	m.Body = strings.TrimSpace(strings.Join(lines, "\n"))
+	return m
+}
+
+var issueFooterPattern = regexp.MustCompile(`(?i)^(closes|refs):\s*(.+)$`)
+
+// ValidateCommitMessage checks m against rules and returns every problem found
+func ValidateCommitMessage(m CommitMessage, rules CommitRules) []string {
+	var problems []string
+
+	// Free-form messages from ParseCommitMessage have no required structure
+	if m.header == "" {
+		allowedTypes := rules.AllowedTypes
+		if len(allowedTypes) == 0 {
+			allowedTypes = ConventionalTypes()
+		}
+		if m.Type == "" {
+			problems = append(problems, "type is required")
+		} else if !containsString(allowedTypes, m.Type) {
+			problems = append(problems, fmt.Sprintf("type %q is not allowed (allowed: %s)", m.Type, strings.Join(allowedTypes, ", ")))
+		}
+
+		if m.Scope == "" && rules.RequireScope {
+			problems = append(problems, "scope is required")
+		}
+		if m.Scope != "" && len(rules.AllowedScopes) > 0 && !containsString(rules.AllowedScopes, m.Scope) {
+			problems = append(problems, fmt.Sprintf("scope %q is not allowed (allowed: %s)", m.Scope, strings.Join(rules.AllowedScopes, ", ")))
+		}
+	}
+
+	subject := strings.TrimSpace(m.Subject)
+	switch {
+	case subject == "":
+		problems = append(problems, "subject is required")
+	case strings.Contains(subject, "\n"):
+		problems = append(problems, "subject must be a single line")
+	}
+	if length := utf8.RuneCountInString(m.Header()); rules.MaxSubjectLength > 0 && length > rules.MaxSubjectLength {
+		problems = append(problems, fmt.Sprintf("header is %d characters long (max %d)", length, rules.MaxSubjectLength))
+	}
+	if strings.HasSuffix(subject, ".") {
+		problems = append(problems, "subject should not end with a period")
+	}
+	if rules.CheckImperative && subject != "" && !isImperative(subject) {
+		problems = append(problems, fmt.Sprintf("subject should use the imperative mood (\"add\", not \"added\" or \"adds\"), found %q", strings.Fields(subject)[0]))
+	}
+
+	for _, ref := range append(append([]string{}, m.Closes...), m.References...) {
+		if !issueRefPattern.MatchString(ref) {
+			problems = append(problems, fmt.Sprintf("invalid issue reference %q (expected #123 or owner/repo#123)", ref))
+		}
+	}
+
+	return problems
+}
+
+var issueRefPattern = regexp.MustCompile(`^([\w.-]+/[\w.-]+)?#\d+$`)
+
+// nonImperativeExceptions are words that look like past tense, gerunds or
+// third person forms but are valid imperative verbs
+var nonImperativeExceptions = map[string]bool{
+	"embed": true, "feed": true, "seed": true, "speed": true, "need": true, "shred": true,
+	"bring": true, "ring": true, "sing": true, "string": true, "swing": true, "ping": true,
+	"access": true, "address": true, "bypass": true, "pass": true, "process": true,
+	"focus": true, "discuss": true, "dismiss": true, "express": true, "miss": true,
+}
+
+// isImperative applies a heuristic to the first word of subject, rejecting
+// past tense ("added"), gerunds ("adding") and third person ("adds")
+func isImperative(subject string) bool {
+	word := strings.ToLower(strings.Trim(strings.Fields(subject)[0], ".,:;!"))
+	if nonImperativeExceptions[word] {
+		return true
+	}
+	if len(word) > 3 && (strings.HasSuffix(word, "ed") || strings.HasSuffix(word, "ing")) {
+		return false
+	}
+	if len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") && !strings.HasSuffix(word, "us") {
+		return false
+	}
+	return true
+}
+
+// containsString reports whether values contains s
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,191 @@
+package git
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseConventionalCommit(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject string
+		body    string
+		wantOK  bool
+		want    ConventionalCommit
+	}{
+		{
+			name:    "type only",
+			subject: "fix: handle nil pointer",
+			wantOK:  true,
+			want:    ConventionalCommit{Type: "fix", Description: "handle nil pointer"},
+		},
+		{
+			name:    "scope and bang",
+			subject: "feat(api)!: remove v1 endpoints",
+			wantOK:  true,
+			want:    ConventionalCommit{Type: "feat", Scope: "api", Description: "remove v1 endpoints", Breaking: true, BreakingNote: "remove v1 endpoints"},
+		},
+		{
+			name:    "breaking footer",
+			subject: "refactor: move config",
+			body:    "Details here.\n\nBREAKING CHANGE: config lives in ~/.githubber now",
+			wantOK:  true,
+			want: ConventionalCommit{
+				Type: "refactor", Description: "move config", Breaking: true,
+				BreakingNote: "config lives in ~/.githubber now",
+				Body:         "Details here.\n\nBREAKING CHANGE: config lives in ~/.githubber now",
+			},
+		},
+		{
+			name:    "not conventional",
+			subject: "Update README",
+			wantOK:  false,
+			want:    ConventionalCommit{Description: "Update README"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseConventionalCommit(tt.subject, tt.body)
+			if ok != tt.wantOK {
+				t.Fatalf("ParseConventionalCommit() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("ParseConventionalCommit() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommitMessageString(t *testing.T) {
+	msg := CommitMessage{
+		Type:           "feat",
+		Scope:          "cli",
+		Subject:        "add commit composer",
+		Body:           "First paragraph.\n\nSecond paragraph.",
+		BreakingChange: "handleCommit no longer takes a single line",
+		Closes:         []string{"#12"},
+		References:     []string{"owner/repo#7"},
+	}
+
+	want := "feat(cli)!: add commit composer\n\n" +
+		"First paragraph.\n\nSecond paragraph.\n\n" +
+		"BREAKING CHANGE: handleCommit no longer takes a single line\n" +
+		"Closes: #12\n" +
+		"Refs: owner/repo#7\n"
+	if got := msg.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	parsed, ok := ParseConventionalCommit(msg.Header(), "BREAKING CHANGE: x")
+	if !ok || parsed.Type != "feat" || parsed.Scope != "cli" || !parsed.Breaking {
+		t.Errorf("rendered header does not round-trip: %+v", parsed)
+	}
+}
+
+func TestValidateCommitMessage(t *testing.T) {
+	rules := CommitRules{
+		MaxSubjectLength: 30,
+		AllowedScopes:    []string{"cli", "git"},
+		CheckImperative:  true,
+	}
+
+	tests := []struct {
+		name      string
+		msg       CommitMessage
+		wantCount int
+		wantText  string
+	}{
+		{name: "valid", msg: CommitMessage{Type: "fix", Scope: "git", Subject: "handle quotes"}},
+		{name: "valid exception verb", msg: CommitMessage{Type: "fix", Subject: "process all refs"}},
+		{name: "unknown type", msg: CommitMessage{Type: "feature", Subject: "add x"}, wantCount: 1, wantText: "type"},
+		{name: "scope not allowed", msg: CommitMessage{Type: "fix", Scope: "ui", Subject: "add x"}, wantCount: 1, wantText: "scope"},
+		{name: "too long", msg: CommitMessage{Type: "fix", Subject: "make this subject much too long"}, wantCount: 1, wantText: "max 30"},
+		{name: "past tense", msg: CommitMessage{Type: "fix", Subject: "added x"}, wantCount: 1, wantText: "imperative"},
+		{name: "third person", msg: CommitMessage{Type: "fix", Subject: "adds x"}, wantCount: 1, wantText: "imperative"},
+		{name: "trailing period", msg: CommitMessage{Type: "fix", Subject: "add x."}, wantCount: 1, wantText: "period"},
+		{name: "bad reference", msg: CommitMessage{Type: "fix", Subject: "add x", Closes: []string{"12"}}, wantCount: 1, wantText: "reference"},
+		{name: "empty", msg: CommitMessage{}, wantCount: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := ValidateCommitMessage(tt.msg, rules)
+			if len(problems) != tt.wantCount {
+				t.Fatalf("ValidateCommitMessage() = %v, want %d problems", problems, tt.wantCount)
+			}
+			if tt.wantText != "" && !strings.Contains(problems[0], tt.wantText) {
+				t.Errorf("problem %q should mention %q", problems[0], tt.wantText)
+			}
+		})
+	}
+
+	rules.RequireScope = true
+	if problems := ValidateCommitMessage(CommitMessage{Type: "fix", Subject: "add x"}, rules); len(problems) != 1 {
+		t.Errorf("RequireScope should reject a missing scope, got %v", problems)
+	}
+}
+
+func TestParseCommitMessage(t *testing.T) {
+	rules := CommitRules{MaxSubjectLength: 27, AllowedScopes: []string{"git"}, RequireScope: true, CheckImperative: true}
+
+	msg := ParseCommitMessage("feat(cli)!: add composer\n\nBody text\n\nBREAKING CHANGE: new flow\nCloses: #3\nRefs: #4, owner/repo#5\n")
+	want := CommitMessage{
+		Type:       "feat",
+		Scope:      "cli",
+		Subject:    "add composer",
+		Body:       "Body text\n\nBREAKING CHANGE: new flow",
+		Closes:     []string{"#3"},
+		References: []string{"#4", "owner/repo#5"},
+		header:     "feat(cli)!: add composer",
+	}
+	if !reflect.DeepEqual(msg, want) {
+		t.Errorf("ParseCommitMessage() = %+v, want %+v", msg, want)
+	}
+	// The scope is not checked against AllowedScopes
+	if problems := ValidateCommitMessage(msg, rules); len(problems) != 0 {
+		t.Errorf("ValidateCommitMessage() = %v, want no problems", problems)
+	}
+
+	msg = ParseCommitMessage("Updated the README")
+	if msg.Type != "" || msg.Subject != "Updated the README" {
+		t.Errorf("ParseCommitMessage() = %+v, want no type and the whole subject", msg)
+	}
+	problems := ValidateCommitMessage(msg, rules)
+	if len(problems) != 1 || !strings.Contains(problems[0], "imperative") {
+		t.Errorf("ValidateCommitMessage() = %v, want only the mood problem", problems)
+	}
+
+	// Length counts characters, not bytes: this header is 27 characters, 30 bytes
+	if problems := ValidateCommitMessage(ParseCommitMessage("Fix the café menü for naïve"), rules); len(problems) != 0 {
+		t.Errorf("ValidateCommitMessage() = %v, want no problems", problems)
+	}
+	if problems := ValidateCommitMessage(ParseCommitMessage("Fix crash\n\nCloses: 12"), rules); len(problems) != 1 || !strings.Contains(problems[0], "reference") {
+		t.Errorf("ValidateCommitMessage() = %v, want an invalid reference", problems)
+	}
+}
+
+func TestCommitMultilineMessage(t *testing.T) {
+	// Set up test repository
+	_, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	createTestFile(t, "test.txt", "content")
+	if err := AddFiles("test.txt"); err != nil {
+		t.Fatalf("AddFiles() error = %v", err)
+	}
+
+	message := "fix: handle \"quotes\" and $vars\n\nBody line with `backticks`.\n\n#12 is not a comment\n"
+	if err := Commit(message); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+
+	got, err := RunCommand("git log -1 --format=%B")
+	if err != nil {
+		t.Fatalf("Failed to read commit message: %v", err)
+	}
+	if got != strings.TrimSpace(message) {
+		t.Errorf("commit message = %q, want %q", got, strings.TrimSpace(message))
+	}
+}
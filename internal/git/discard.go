@@ -0,0 +1,164 @@
+/*
+ * GitHubber - Git Discard and Clean Operations
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Discarding changes and removing untracked files with previews and safety snapshots
+ */
+
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DiscardOptions selects which changes DiscardChanges throws away
+type DiscardOptions struct {
+	Paths    []string
+	Staged   bool // Unstage changes, resetting the index to HEAD
+	Worktree bool // Discard unstaged changes in the working tree
+}
+
+// CleanOptions controls which untracked files Clean removes
+type CleanOptions struct {
+	Paths       []string
+	Directories bool // Also remove untracked directories
+	Ignored     bool // Also remove ignored files
+	OnlyIgnored bool // Only remove ignored files
+}
+
+// PreviewDiscard returns a diffstat of the changes DiscardChanges would discard
+func PreviewDiscard(opts DiscardOptions) (string, error) {
+	if len(opts.Paths) == 0 {
+		return "", fmt.Errorf("no paths given")
+	}
+
+	var command string
+	switch {
+	case opts.Staged && opts.Worktree:
+		command = "git diff --stat HEAD"
+	case opts.Staged:
+		command = "git diff --stat --cached"
+	case opts.Worktree:
+		command = "git diff --stat"
+	default:
+		return "", fmt.Errorf("nothing to discard: choose staged and/or working tree changes")
+	}
+
+	output, err := RunCommand(command + " -- " + quoteArgs(opts.Paths))
+	if err != nil {
+		return "", fmt.Errorf("failed to preview changes: %s", output)
+	}
+	return output, nil
+}
+
+// DiscardChanges restores the index and/or working tree of opts.Paths.
+// Discarding staged changes alone unstages them; discarding both restores
+// the files to HEAD.
+func DiscardChanges(opts DiscardOptions) error {
+	if len(opts.Paths) == 0 {
+		return fmt.Errorf("no paths given")
+	}
+
+	command := "git restore"
+	switch {
+	case opts.Staged && opts.Worktree:
+		command += " --source=HEAD --staged --worktree"
+	case opts.Staged:
+		command += " --staged"
+	case opts.Worktree:
+		command += " --worktree"
+	default:
+		return fmt.Errorf("nothing to discard: choose staged and/or working tree changes")
+	}
+
+	output, err := RunCommand(command + " -- " + quoteArgs(opts.Paths))
+	if err != nil {
+		return fmt.Errorf("failed to discard changes: %s", output)
+	}
+	return nil
+}
+
+// cleanCommand builds the git clean invocation for opts with the given mode flag
+func cleanCommand(mode string, opts CleanOptions) string {
+	command := "git clean " + mode
+	if opts.Directories {
+		command += " -d"
+	}
+	if opts.OnlyIgnored {
+		command += " -X"
+	} else if opts.Ignored {
+		command += " -x"
+	}
+	if len(opts.Paths) > 0 {
+		command += " -- " + quoteArgs(opts.Paths)
+	}
+	return command
+}
+
+// PreviewClean returns the paths Clean would remove
+func PreviewClean(opts CleanOptions) ([]string, error) {
+	output, err := RunCommand(cleanCommand("-n", opts))
+	if err != nil {
+		return nil, fmt.Errorf("failed to preview clean: %s", output)
+	}
+	return parseCleanOutput(output, "Would remove "), nil
+}
+
+// Clean removes untracked files according to opts and returns the removed paths
+func Clean(opts CleanOptions) ([]string, error) {
+	output, err := RunCommand(cleanCommand("-f", opts))
+	if err != nil {
+		return nil, fmt.Errorf("failed to clean: %s", output)
+	}
+	return parseCleanOutput(output, "Removing "), nil
+}
+
+// parseCleanOutput extracts paths from git clean output lines starting with prefix
+func parseCleanOutput(output, prefix string) []string {
+	var paths []string
+	for _, line := range strings.Split(output, "\n") {
+		if path, ok := strings.CutPrefix(line, prefix); ok {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// SafetyStash records the current changes in the stash without touching
+// the working tree, so they can be recovered after a discard or clean.
+// Untracked and ignored files are included on request. It returns the stash
+// ref, or "" when there was nothing to record.
+func SafetyStash(message string, untracked, ignored bool) (string, error) {
+	if !untracked && !ignored {
+		hash, err := RunCommand(fmt.Sprintf("git stash create %s", quoteArg(message)))
+		if err != nil {
+			return "", fmt.Errorf("failed to create safety stash: %s", hash)
+		}
+		if hash == "" {
+			return "", nil
+		}
+		if output, err := RunCommand(fmt.Sprintf("git stash store -m %s %s", quoteArg(message), hash)); err != nil {
+			return "", fmt.Errorf("failed to store safety stash: %s", output)
+		}
+		return stashRef(0), nil
+	}
+
+	// stash create cannot record untracked files, so push and re-apply
+	flag := " --include-untracked"
+	if ignored {
+		flag = " --all"
+	}
+	before, _ := RunCommand("git rev-parse --quiet --verify refs/stash")
+	output, err := RunCommand(fmt.Sprintf("git stash push%s -m %s", flag, quoteArg(message)))
+	if err != nil {
+		return "", fmt.Errorf("failed to create safety stash: %s", output)
+	}
+	after, _ := RunCommand("git rev-parse --quiet --verify refs/stash")
+	if after == before {
+		return "", nil
+	}
+	if output, err := RunCommand(fmt.Sprintf("git stash apply --index %s", stashRef(0))); err != nil {
+		return "", fmt.Errorf("changes were stashed in %s but could not be restored: %s", stashRef(0), output)
+	}
+	return stashRef(0), nil
+}
@@ -0,0 +1,105 @@
+package git
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestDiscardChanges(t *testing.T) {
+	// Set up test repository
+	_, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	createTestFile(t, "a.txt", "a")
+	createTestFile(t, "b.txt", "b")
+	createTestCommit(t, "Initial commit")
+
+	createTestFile(t, "a.txt", "a changed")
+	createTestFile(t, "b.txt", "b changed")
+	AddFiles("b.txt")
+
+	preview, err := PreviewDiscard(DiscardOptions{Paths: []string{"a.txt", "b.txt"}, Worktree: true})
+	if err != nil {
+		t.Fatalf("PreviewDiscard() error = %v", err)
+	}
+	if preview == "" {
+		t.Error("PreviewDiscard() should list the unstaged change to a.txt")
+	}
+
+	ref, err := SafetyStash("safety", false, false)
+	if err != nil || ref != "stash@{0}" {
+		t.Fatalf("SafetyStash() = %q, %v", ref, err)
+	}
+	assertFileContent(t, "a.txt", "a changed")
+
+	if err := DiscardChanges(DiscardOptions{Paths: []string{"a.txt"}, Worktree: true}); err != nil {
+		t.Fatalf("DiscardChanges() error = %v", err)
+	}
+	assertFileContent(t, "a.txt", "a")
+
+	// Unstaging keeps the working tree change
+	if err := DiscardChanges(DiscardOptions{Paths: []string{"b.txt"}, Staged: true}); err != nil {
+		t.Fatalf("DiscardChanges() error = %v", err)
+	}
+	if staged, _ := RunCommand("git diff --cached --name-only"); staged != "" {
+		t.Errorf("staged = %q, want nothing staged", staged)
+	}
+	assertFileContent(t, "b.txt", "b changed")
+
+	AddFiles("b.txt")
+	if err := DiscardChanges(DiscardOptions{Paths: []string{"b.txt"}, Staged: true, Worktree: true}); err != nil {
+		t.Fatalf("DiscardChanges() error = %v", err)
+	}
+	assertFileContent(t, "b.txt", "b")
+
+	// The safety stash still holds the discarded change
+	if err := ApplyStash(0); err != nil {
+		t.Fatalf("ApplyStash() error = %v", err)
+	}
+	assertFileContent(t, "a.txt", "a changed")
+}
+
+func TestCleanWithPreview(t *testing.T) {
+	// Set up test repository
+	_, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	createTestFile(t, ".gitignore", "*.log\n")
+	createTestCommit(t, "Initial commit")
+
+	createTestFile(t, "untracked.txt", "untracked")
+	createTestFile(t, "debug.log", "ignored")
+	os.Mkdir("build", 0755)
+	createTestFile(t, "build/out.txt", "out")
+
+	preview, err := PreviewClean(CleanOptions{})
+	if err != nil {
+		t.Fatalf("PreviewClean() error = %v", err)
+	}
+	if !reflect.DeepEqual(preview, []string{"untracked.txt"}) {
+		t.Errorf("PreviewClean() = %v, want [untracked.txt]", preview)
+	}
+
+	preview, _ = PreviewClean(CleanOptions{Directories: true, OnlyIgnored: true})
+	if !reflect.DeepEqual(preview, []string{"debug.log"}) {
+		t.Errorf("PreviewClean(OnlyIgnored) = %v, want [debug.log]", preview)
+	}
+
+	ref, err := SafetyStash("safety", true, true)
+	if err != nil || ref == "" {
+		t.Fatalf("SafetyStash() = %q, %v", ref, err)
+	}
+	assertFileExists(t, "debug.log")
+
+	removed, err := Clean(CleanOptions{Directories: true, Ignored: true})
+	if err != nil {
+		t.Fatalf("Clean() error = %v", err)
+	}
+	if !reflect.DeepEqual(removed, []string{"build/", "debug.log", "untracked.txt"}) {
+		t.Errorf("Clean() = %v", removed)
+	}
+	if _, err := os.Stat("untracked.txt"); !os.IsNotExist(err) {
+		t.Error("untracked.txt should have been removed")
+	}
+}
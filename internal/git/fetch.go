@@ -0,0 +1,195 @@
+/*
+ * GitHubber - Git Fetch Operations
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Parallel fetching with pruning, progress reporting and ref update summaries
+ */
+
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// RefUpdateKind describes how a fetch changed a local ref
+type RefUpdateKind string
+
+const (
+	RefCreated  RefUpdateKind = "created"
+	RefUpdated  RefUpdateKind = "updated"
+	RefForced   RefUpdateKind = "forced"
+	RefDeleted  RefUpdateKind = "deleted"
+	RefRejected RefUpdateKind = "rejected"
+)
+
+// RefUpdate is a single ref change reported by git fetch
+type RefUpdate struct {
+	Kind    RefUpdateKind
+	Ref     string // Local ref, e.g. origin/main or v1.0.0
+	Source  string // Remote ref name, "(none)" for deletions
+	Summary string // e.g. "1a2b3c4..5d6e7f8" or "[new branch]"
+}
+
+// FetchProgress is a progress report emitted while fetching
+type FetchProgress struct {
+	Remote  string
+	Phase   string // e.g. "Receiving objects"
+	Percent int
+}
+
+// FetchOptions controls FetchRemote and FetchAll
+type FetchOptions struct {
+	Prune     bool // Remove remote-tracking branches deleted on the remote
+	PruneTags bool // Also remove local tags deleted on the remote
+	// Progress is called for every progress update. FetchAll calls it from
+	// several goroutines, so it must be safe for concurrent use.
+	Progress func(FetchProgress)
+}
+
+// FetchResult is the outcome of fetching one remote
+type FetchResult struct {
+	Remote  string
+	Updates []RefUpdate
+	Err     error
+}
+
+var (
+	fetchProgressPattern = regexp.MustCompile(`^(?:remote: )?([A-Za-z ]+):\s+(\d+)%`)
+	fetchUpdatePattern   = regexp.MustCompile(`^ ([ +\-t*!=]) (\[[^\]]+\]|\S+)\s+(\S+)\s+-> (\S+)`)
+)
+
+// FetchRemote fetches a single remote, reporting progress through
+// opts.Progress and returning the refs that changed
+func FetchRemote(remote string, opts FetchOptions) FetchResult {
+	result := FetchResult{Remote: remote}
+
+	command := "git fetch --progress"
+	if opts.Prune || opts.PruneTags {
+		command += " --prune"
+	}
+	if opts.PruneTags {
+		command += " --prune-tags"
+	}
+	command += " " + quoteArg(remote)
+
+	cmd := exec.Command("sh", "-c", command)
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		result.Err = fmt.Errorf("failed to fetch %s: %w", remote, err)
+		return result
+	}
+	if err := cmd.Start(); err != nil {
+		result.Err = fmt.Errorf("failed to fetch %s: %w", remote, err)
+		return result
+	}
+
+	var output []string
+	scanner := bufio.NewScanner(stderr)
+	scanner.Split(scanProgressLines)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if progress, ok := parseFetchProgress(line); ok {
+			if opts.Progress != nil {
+				progress.Remote = remote
+				opts.Progress(progress)
+			}
+			continue
+		}
+		if update, ok := parseRefUpdate(line); ok {
+			result.Updates = append(result.Updates, update)
+			continue
+		}
+		if strings.TrimSpace(line) != "" {
+			output = append(output, line)
+		}
+	}
+
+	if err := cmd.Wait(); err != nil {
+		result.Err = fmt.Errorf("failed to fetch %s: %s", remote, strings.Join(output, "\n"))
+	}
+	return result
+}
+
+// FetchAll fetches every configured remote in parallel. Results are
+// returned in remote configuration order.
+func FetchAll(opts FetchOptions) ([]FetchResult, error) {
+	remotes, err := RemoteNames()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remotes: %w", err)
+	}
+	if len(remotes) == 0 {
+		return nil, fmt.Errorf("no remotes configured")
+	}
+
+	results := make([]FetchResult, len(remotes))
+	var wg sync.WaitGroup
+	for i, remote := range remotes {
+		wg.Add(1)
+		go func(i int, remote string) {
+			defer wg.Done()
+			results[i] = FetchRemote(remote, opts)
+		}(i, remote)
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// parseFetchProgress parses a progress line such as
+// "Receiving objects:  45% (9/20)"
+func parseFetchProgress(line string) (FetchProgress, bool) {
+	match := fetchProgressPattern.FindStringSubmatch(line)
+	if match == nil {
+		return FetchProgress{}, false
+	}
+	percent, _ := strconv.Atoi(match[2])
+	return FetchProgress{Phase: match[1], Percent: percent}, true
+}
+
+// parseRefUpdate parses a ref update line of the fetch summary, e.g.
+// " + 1a2b3c4...5d6e7f8 main -> origin/main  (forced update)"
+func parseRefUpdate(line string) (RefUpdate, bool) {
+	match := fetchUpdatePattern.FindStringSubmatch(line)
+	if match == nil {
+		return RefUpdate{}, false
+	}
+
+	update := RefUpdate{Summary: match[2], Source: match[3], Ref: match[4]}
+	switch match[1] {
+	case "*":
+		update.Kind = RefCreated
+	case "+":
+		update.Kind = RefForced
+	case "-":
+		update.Kind = RefDeleted
+	case "!":
+		update.Kind = RefRejected
+	case "=":
+		// Up to date refs are only listed with --verbose
+		return RefUpdate{}, false
+	default:
+		update.Kind = RefUpdated
+	}
+	return update, true
+}
+
+// scanProgressLines is a bufio.SplitFunc that splits on both carriage
+// returns and newlines, since git redraws progress lines with \r
+func scanProgressLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
@@ -0,0 +1,107 @@
+package git
+
+import (
+	"bufio"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRefUpdate(t *testing.T) {
+	tests := []struct {
+		line string
+		want RefUpdate
+		ok   bool
+	}{
+		{" * [new branch]      feature    -> origin/feature", RefUpdate{RefCreated, "origin/feature", "feature", "[new branch]"}, true},
+		{" * [new tag]         v1.0.0     -> v1.0.0", RefUpdate{RefCreated, "v1.0.0", "v1.0.0", "[new tag]"}, true},
+		{"   1a2b3c4..5d6e7f8  main       -> origin/main", RefUpdate{RefUpdated, "origin/main", "main", "1a2b3c4..5d6e7f8"}, true},
+		{" + 1a2b3c4...5d6e7f8 topic      -> origin/topic  (forced update)", RefUpdate{RefForced, "origin/topic", "topic", "1a2b3c4...5d6e7f8"}, true},
+		{" - [deleted]         (none)     -> origin/old", RefUpdate{RefDeleted, "origin/old", "(none)", "[deleted]"}, true},
+		{" = [up to date]      main       -> origin/main", RefUpdate{}, false},
+		{"From /tmp/remote", RefUpdate{}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRefUpdate(tt.line)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseRefUpdate(%q) = %+v, %v, want %+v, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseFetchProgress(t *testing.T) {
+	// git rewrites progress lines in place with \r and ends each phase with \n
+	stderr := "remote: Counting objects:  45% (9/20)\rremote: Counting objects: 100% (20/20), done.\n" +
+		"Receiving objects:  50% (5/10)\rReceiving objects: 100% (10/10), done.\n" +
+		"From /tmp/remote\n * [new branch]      feature    -> origin/feature"
+
+	var got []FetchProgress
+	scanner := bufio.NewScanner(strings.NewReader(stderr))
+	scanner.Split(scanProgressLines)
+	for scanner.Scan() {
+		if progress, ok := parseFetchProgress(scanner.Text()); ok {
+			got = append(got, progress)
+		}
+	}
+
+	want := []FetchProgress{
+		{Phase: "Counting objects", Percent: 45},
+		{Phase: "Counting objects", Percent: 100},
+		{Phase: "Receiving objects", Percent: 50},
+		{Phase: "Receiving objects", Percent: 100},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("progress = %+v, want %+v", got, want)
+	}
+}
+
+func TestFetchAll(t *testing.T) {
+	// Set up test repository
+	_, cleanup := setupTestRepo(t)
+	defer cleanup()
+	remoteDir, cleanupRemote := setupTestRemote(t, "origin")
+	defer cleanupRemote()
+	_, cleanupOther := setupTestRemote(t, "other")
+	defer cleanupOther()
+
+	createTestFile(t, "test.txt", "initial")
+	createTestCommit(t, "Initial commit")
+	if _, err := RunCommand("git push -q origin HEAD:main HEAD:old"); err != nil {
+		t.Fatalf("Failed to push: %v", err)
+	}
+	if _, err := RunCommand("git fetch -q origin"); err != nil {
+		t.Fatalf("Failed to fetch: %v", err)
+	}
+
+	// Change the remote behind our back: delete old, add feature
+	script := "git --git-dir=" + quoteArg(remoteDir) + " branch -D old && git --git-dir=" + quoteArg(remoteDir) + " branch feature main"
+	if output, err := exec.Command("sh", "-c", script).CombinedOutput(); err != nil {
+		t.Fatalf("Failed to update remote: %v: %s", err, output)
+	}
+
+	results, err := FetchAll(FetchOptions{Prune: true})
+	if err != nil {
+		t.Fatalf("FetchAll() error = %v", err)
+	}
+	if len(results) != 2 || results[0].Remote != "origin" || results[1].Remote != "other" {
+		t.Fatalf("FetchAll() = %+v, want results for origin and other", results)
+	}
+	for _, result := range results {
+		if result.Err != nil {
+			t.Errorf("fetch %s error = %v", result.Remote, result.Err)
+		}
+	}
+
+	kinds := map[string]RefUpdateKind{}
+	for _, update := range results[0].Updates {
+		kinds[update.Ref] = update.Kind
+	}
+	if kinds["origin/feature"] != RefCreated || kinds["origin/old"] != RefDeleted {
+		t.Errorf("origin updates = %+v, want feature created and old deleted", results[0].Updates)
+	}
+	if _, err := RunCommand("git rev-parse --verify --quiet refs/remotes/origin/old"); err == nil {
+		t.Error("origin/old should have been pruned")
+	}
+}
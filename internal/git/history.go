@@ -0,0 +1,78 @@
+/*
+ * GitHubber - Git History
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Structured access to commit history between revisions
+ */
+
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CommitDetail describes a commit with its full message
+type CommitDetail struct {
+	Hash    string
+	Subject string
+	Body    string
+	Author  string
+	Date    time.Time
+}
+
+// ShortHash returns the abbreviated commit hash
+func (c CommitDetail) ShortHash() string {
+	if len(c.Hash) > 7 {
+		return c.Hash[:7]
+	}
+	return c.Hash
+}
+
+const commitDetailFormat = "%H%x1f%s%x1f%b%x1f%an%x1f%ct%x1e"
+
+// CommitsInRange returns the commits reachable from to but not from from,
+// newest first. An empty from lists the entire history of to.
+func CommitsInRange(from, to string) ([]CommitDetail, error) {
+	if to == "" {
+		to = "HEAD"
+	}
+	revision := quoteArg(to)
+	if from != "" {
+		revision = quoteArg(from + ".." + to)
+	}
+
+	output, err := RunCommand(fmt.Sprintf("git log --format=%s %s --", quoteArg(commitDetailFormat), revision))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	return parseCommitDetails(output)
+}
+
+// parseCommitDetails parses records produced with commitDetailFormat
+func parseCommitDetails(output string) ([]CommitDetail, error) {
+	var commits []CommitDetail
+	for _, record := range strings.Split(output, "\x1e") {
+		record = strings.TrimPrefix(record, "\n")
+		if strings.TrimSpace(record) == "" {
+			continue
+		}
+		fields := strings.Split(record, "\x1f")
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("unexpected log record: %q", record)
+		}
+		commit := CommitDetail{
+			Hash:    fields[0],
+			Subject: fields[1],
+			Body:    strings.TrimSpace(fields[2]),
+			Author:  fields[3],
+		}
+		if ts, err := strconv.ParseInt(strings.TrimSpace(fields[4]), 10, 64); err == nil {
+			commit.Date = time.Unix(ts, 0)
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
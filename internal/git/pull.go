@@ -0,0 +1,193 @@
+/*
+ * GitHubber - Git Pull Operations
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Pulling with merge, rebase or fast-forward-only modes and structured pull summaries
+ */
+
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PullMode selects how pulled changes are integrated
+type PullMode string
+
+const (
+	PullModeMerge       PullMode = "merge"
+	PullModeRebase      PullMode = "rebase"
+	PullModeFastForward PullMode = "ff-only"
+)
+
+// pullModeConfigKey is the repository-local git config key holding the pull mode
+const pullModeConfigKey = "githubber.pullMode"
+
+// ParsePullMode parses a pull mode name
+func ParsePullMode(s string) (PullMode, error) {
+	switch mode := PullMode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case PullModeMerge, PullModeRebase, PullModeFastForward:
+		return mode, nil
+	}
+	return "", fmt.Errorf("invalid pull mode: %s (valid modes: merge, rebase, ff-only)", s)
+}
+
+// RepoPullMode returns the pull mode stored for the current repository
+func RepoPullMode() (PullMode, bool) {
+	value, err := RunCommand(fmt.Sprintf("git config --local --get %s", pullModeConfigKey))
+	if err != nil {
+		return "", false
+	}
+	mode, err := ParsePullMode(value)
+	return mode, err == nil
+}
+
+// SetRepoPullMode stores the pull mode for the current repository
+func SetRepoPullMode(mode PullMode) error {
+	output, err := RunCommand(fmt.Sprintf("git config --local %s %s", pullModeConfigKey, quoteArg(string(mode))))
+	if err != nil {
+		return fmt.Errorf("failed to save pull mode: %s", output)
+	}
+	return nil
+}
+
+// PullOptions controls PullWithOptions
+type PullOptions struct {
+	Remote    string
+	Branch    string
+	Mode      PullMode
+	Autostash bool // Stash local changes before pulling and reapply them afterwards
+}
+
+// FileChange describes how a pull changed a file
+type FileChange struct {
+	Path      string
+	Status    string // A, M, D or T as reported by git diff --name-status
+	Additions int    // -1 for binary files
+	Deletions int    // -1 for binary files
+}
+
+// PullSummary describes what a pull brought in
+type PullSummary struct {
+	From    string // HEAD before the pull
+	To      string // HEAD after the pull
+	Commits []CommitDetail
+	Files   []FileChange
+}
+
+// UpToDate reports whether the pull changed nothing
+func (s *PullSummary) UpToDate() bool {
+	return s.From == s.To
+}
+
+// PullWithOptions pulls according to opts and summarises the incoming
+// commits and changed files. A pull that stops on conflicts is aborted,
+// leaving the branch as it was.
+func PullWithOptions(opts PullOptions) (*PullSummary, error) {
+	var command string
+	switch opts.Mode {
+	case PullModeMerge, "":
+		command = "git pull --no-rebase"
+	case PullModeRebase:
+		command = "git pull --rebase"
+	case PullModeFastForward:
+		command = "git pull --ff-only"
+	default:
+		return nil, fmt.Errorf("invalid pull mode: %s", opts.Mode)
+	}
+	if opts.Autostash {
+		command += " --autostash"
+	}
+	command += " " + quoteArg(opts.Remote) + " " + quoteArg(opts.Branch)
+
+	before, err := RunCommand("git rev-parse --verify --quiet HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("cannot pull into a repository without commits")
+	}
+
+	output, err := RunCommand(command)
+	if err != nil {
+		abortPull()
+		return nil, fmt.Errorf("pull from %s/%s failed: %s", opts.Remote, opts.Branch, output)
+	}
+
+	after, err := RunCommand("git rev-parse HEAD")
+	if err != nil {
+		return nil, err
+	}
+	summary := &PullSummary{From: before, To: after}
+	if summary.UpToDate() {
+		return summary, nil
+	}
+
+	// Incoming commits are those fetched that were not in the old HEAD;
+	// with rebase, the replayed local commits are not counted
+	if summary.Commits, err = CommitsInRange(before, "FETCH_HEAD"); err != nil {
+		return nil, err
+	}
+	if summary.Files, err = changedFiles(before, after); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// abortPull aborts an interrupted rebase or merge left behind by a failed pull
+func abortPull() {
+	if _, err := RunCommand("git rev-parse --verify --quiet REBASE_HEAD"); err == nil {
+		RunCommand("git rebase --abort")
+		return
+	}
+	if _, err := RunCommand("git rev-parse --verify --quiet MERGE_HEAD"); err == nil {
+		RunCommand("git merge --abort")
+	}
+}
+
+// changedFiles returns the files that differ between two commits
+func changedFiles(from, to string) ([]FileChange, error) {
+	statuses, err := RunCommand(fmt.Sprintf("git diff --no-renames --name-status %s %s", quoteArg(from), quoteArg(to)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changed files: %w", err)
+	}
+	numstat, err := RunCommand(fmt.Sprintf("git diff --no-renames --numstat %s %s", quoteArg(from), quoteArg(to)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changed files: %w", err)
+	}
+	return parseFileChanges(statuses, numstat), nil
+}
+
+// parseFileChanges combines git diff --name-status and --numstat output
+func parseFileChanges(statuses, numstat string) []FileChange {
+	counts := make(map[string][2]int)
+	for _, line := range strings.Split(numstat, "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		additions, err := strconv.Atoi(fields[0])
+		if err != nil {
+			additions = -1
+		}
+		deletions, err := strconv.Atoi(fields[1])
+		if err != nil {
+			deletions = -1
+		}
+		counts[fields[2]] = [2]int{additions, deletions}
+	}
+
+	var files []FileChange
+	for _, line := range strings.Split(statuses, "\n") {
+		fields := strings.SplitN(line, "\t", 2)
+		if len(fields) != 2 {
+			continue
+		}
+		count := counts[fields[1]]
+		files = append(files, FileChange{
+			Path:      fields[1],
+			Status:    fields[0],
+			Additions: count[0],
+			Deletions: count[1],
+		})
+	}
+	return files
+}
@@ -0,0 +1,93 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+func TestParseFileChanges(t *testing.T) {
+	statuses := "M\tREADME.md\nA\tlogo.png\nD\told.txt"
+	numstat := "3\t1\tREADME.md\n-\t-\tlogo.png\n0\t12\told.txt"
+
+	want := []FileChange{
+		{Path: "README.md", Status: "M", Additions: 3, Deletions: 1},
+		{Path: "logo.png", Status: "A", Additions: -1, Deletions: -1},
+		{Path: "old.txt", Status: "D", Additions: 0, Deletions: 12},
+	}
+	if got := parseFileChanges(statuses, numstat); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseFileChanges() = %+v, want %+v", got, want)
+	}
+}
+
+func TestPullModes(t *testing.T) {
+	// Set up test repository
+	_, cleanup := setupTestRepo(t)
+	defer cleanup()
+	remoteDir, cleanupRemote := setupTestRemote(t, "origin")
+	defer cleanupRemote()
+
+	if _, ok := RepoPullMode(); ok {
+		t.Error("RepoPullMode() should be unset in a new repository")
+	}
+	if err := SetRepoPullMode(PullModeRebase); err != nil {
+		t.Fatalf("SetRepoPullMode() error = %v", err)
+	}
+	if mode, ok := RepoPullMode(); !ok || mode != PullModeRebase {
+		t.Errorf("RepoPullMode() = %q, %v, want rebase", mode, ok)
+	}
+
+	createTestFile(t, "test.txt", "initial")
+	createTestCommit(t, "Initial commit")
+	branch, _ := CurrentBranch()
+	if _, err := PushCurrentBranch(); err != nil {
+		t.Fatalf("PushCurrentBranch() error = %v", err)
+	}
+
+	// Add a remote commit from another clone
+	otherDir, err := os.MkdirTemp("", "git-tool-clone-*")
+	if err != nil {
+		t.Fatalf("Failed to create clone directory: %v", err)
+	}
+	defer os.RemoveAll(otherDir)
+	script := "git clone -q " + quoteArg(remoteDir) + " " + quoteArg(otherDir) + " && cd " + quoteArg(otherDir) +
+		" && echo remote > remote.txt && git add remote.txt" +
+		" && git -c user.name=Other -c user.email=other@example.com commit -q -m 'Remote commit' && git push -q"
+	if output, err := exec.Command("sh", "-c", script).CombinedOutput(); err != nil {
+		t.Fatalf("Failed to advance remote: %v: %s", err, output)
+	}
+
+	// A local commit makes fast-forward impossible
+	createTestFile(t, "local.txt", "local")
+	createTestCommit(t, "Local commit")
+	before, _ := RunCommand("git rev-parse HEAD")
+	if _, err := PullWithOptions(PullOptions{Remote: "origin", Branch: branch, Mode: PullModeFastForward}); err == nil {
+		t.Error("PullWithOptions(ff-only) should fail on diverged history")
+	}
+	if head, _ := RunCommand("git rev-parse HEAD"); head != before {
+		t.Error("failed pull should leave HEAD unchanged")
+	}
+
+	// Rebase with a dirty working tree, relying on autostash
+	createTestFile(t, "test.txt", "dirty")
+	summary, err := PullWithOptions(PullOptions{Remote: "origin", Branch: branch, Mode: PullModeRebase, Autostash: true})
+	if err != nil {
+		t.Fatalf("PullWithOptions(rebase) error = %v", err)
+	}
+	assertFileContent(t, "test.txt", "dirty")
+	if len(summary.Commits) != 1 || summary.Commits[0].Subject != "Remote commit" {
+		t.Errorf("summary commits = %+v, want the remote commit only", summary.Commits)
+	}
+	if len(summary.Files) != 1 || summary.Files[0] != (FileChange{Path: "remote.txt", Status: "A", Additions: 1}) {
+		t.Errorf("summary files = %+v", summary.Files)
+	}
+	if subjects, _ := RunCommand("git log --format=%s"); subjects != "Local commit\nRemote commit\nInitial commit" {
+		t.Errorf("history = %q, want local commit rebased on top", subjects)
+	}
+
+	summary, err = PullWithOptions(PullOptions{Remote: "origin", Branch: branch, Mode: PullModeMerge, Autostash: true})
+	if err != nil || !summary.UpToDate() {
+		t.Errorf("second pull = %+v, %v, want up to date", summary, err)
+	}
+}
@@ -0,0 +1,206 @@
+/*
+ * GitHubber - Git Push Operations
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Upstream-aware pushing with classified push rejections
+ */
+
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PushOptions controls PushBranch
+type PushOptions struct {
+	Remote      string
+	Branch      string // Local branch to push
+	RemoteRef   string // Remote branch name, defaults to Branch
+	SetUpstream bool
+	// ForceWithLease overwrites the remote branch only if it still points
+	// where our remote-tracking branch says it does
+	ForceWithLease bool
+}
+
+// PushResult describes a successful push
+type PushResult struct {
+	Remote      string
+	Branch      string
+	RemoteRef   string
+	SetUpstream bool
+	UpToDate    bool
+}
+
+// PushRejection is the reason a remote refused a push
+type PushRejection string
+
+const (
+	RejectionNonFastForward  PushRejection = "non-fast-forward"
+	RejectionProtectedBranch PushRejection = "protected-branch"
+	// RejectionStaleLease means a --force-with-lease push found the remote
+	// branch moved since it was last fetched, so nothing was overwritten
+	RejectionStaleLease PushRejection = "stale-lease"
+	RejectionOther      PushRejection = "rejected"
+)
+
+// PushRejectedError is returned when the remote refuses to update a branch
+type PushRejectedError struct {
+	Reason PushRejection
+	Remote string
+	Branch string
+	Output string
+}
+
+func (e *PushRejectedError) Error() string {
+	switch e.Reason {
+	case RejectionNonFastForward:
+		return fmt.Sprintf("push to %s/%s rejected: the remote contains commits you do not have locally", e.Remote, e.Branch)
+	case RejectionProtectedBranch:
+		return fmt.Sprintf("push to %s/%s rejected: the branch is protected", e.Remote, e.Branch)
+	case RejectionStaleLease:
+		return fmt.Sprintf("push to %s/%s rejected: the remote branch changed since you last fetched", e.Remote, e.Branch)
+	default:
+		return fmt.Sprintf("push to %s/%s rejected: %s", e.Remote, e.Branch, e.Output)
+	}
+}
+
+// CurrentBranch returns the name of the checked out branch
+func CurrentBranch() (string, error) {
+	branch, err := RunCommand("git symbolic-ref --quiet --short HEAD")
+	if err != nil {
+		return "", fmt.Errorf("not on a branch (detached HEAD)")
+	}
+	return branch, nil
+}
+
+// Upstream returns the remote and remote branch name that branch tracks
+func Upstream(branch string) (remote, remoteBranch string, ok bool) {
+	remote, err := RunCommand(fmt.Sprintf("git config --get %s", quoteArg("branch."+branch+".remote")))
+	if err != nil || remote == "" || remote == "." {
+		return "", "", false
+	}
+	merge, err := RunCommand(fmt.Sprintf("git config --get %s", quoteArg("branch."+branch+".merge")))
+	if err != nil || merge == "" {
+		return "", "", false
+	}
+	return remote, strings.TrimPrefix(merge, "refs/heads/"), true
+}
+
+// PushBranch pushes a branch according to opts. A rejection by the remote
+// is reported as a *PushRejectedError.
+func PushBranch(opts PushOptions) (*PushResult, error) {
+	if opts.RemoteRef == "" {
+		opts.RemoteRef = opts.Branch
+	}
+
+	command := "git push --porcelain"
+	if opts.SetUpstream {
+		command += " --set-upstream"
+	}
+	if opts.ForceWithLease {
+		command += " --force-with-lease"
+	}
+	refspec := fmt.Sprintf("refs/heads/%s:refs/heads/%s", opts.Branch, opts.RemoteRef)
+	command += fmt.Sprintf(" %s %s", quoteArg(opts.Remote), quoteArg(refspec))
+
+	output, err := RunCommand(command)
+	if err != nil {
+		if reason, rejected := classifyPushRejection(output); rejected {
+			return nil, &PushRejectedError{Reason: reason, Remote: opts.Remote, Branch: opts.RemoteRef, Output: output}
+		}
+		return nil, fmt.Errorf("push failed: %s", output)
+	}
+
+	return &PushResult{
+		Remote:      opts.Remote,
+		Branch:      opts.Branch,
+		RemoteRef:   opts.RemoteRef,
+		SetUpstream: opts.SetUpstream,
+		UpToDate:    strings.Contains(output, "[up to date]"),
+	}, nil
+}
+
+// CurrentPushOptions returns the options that push the checked out branch
+// to its upstream
+func CurrentPushOptions() (PushOptions, error) {
+	branch, err := CurrentBranch()
+	if err != nil {
+		return PushOptions{}, err
+	}
+	return BranchPushOptions(branch)
+}
+
+// BranchPushOptions returns the options that push branch to its upstream.
+// Branches without an upstream are pushed to the default remote under the
+// same name and the upstream is set.
+func BranchPushOptions(branch string) (PushOptions, error) {
+	if remote, remoteBranch, ok := Upstream(branch); ok {
+		// Checked out pull requests may track refs/pull/<n>/head, which
+		// GitHub does not accept pushes to
+		if strings.HasPrefix(remoteBranch, "refs/") {
+			return PushOptions{}, fmt.Errorf("branch %s tracks %s on %s, which cannot be pushed to", branch, remoteBranch, remote)
+		}
+		return PushOptions{Remote: remote, Branch: branch, RemoteRef: remoteBranch}, nil
+	}
+
+	remote, err := DefaultRemote()
+	if err != nil {
+		return PushOptions{}, err
+	}
+	return PushOptions{Remote: remote, Branch: branch, RemoteRef: branch, SetUpstream: true}, nil
+}
+
+// PushCurrentBranch pushes the checked out branch to its upstream, setting
+// the upstream on first push
+func PushCurrentBranch() (*PushResult, error) {
+	opts, err := CurrentPushOptions()
+	if err != nil {
+		return nil, err
+	}
+	return PushBranch(opts)
+}
+
+// classifyPushRejection inspects git push output for a rejected ref update
+func classifyPushRejection(output string) (PushRejection, bool) {
+	lower := strings.ToLower(output)
+	switch {
+	case strings.Contains(lower, "protected branch") || strings.Contains(output, "GH006"):
+		return RejectionProtectedBranch, true
+	case strings.Contains(lower, "stale info"):
+		return RejectionStaleLease, true
+	case strings.Contains(lower, "non-fast-forward") || strings.Contains(lower, "fetch first"):
+		return RejectionNonFastForward, true
+	case strings.Contains(lower, "[rejected]") || strings.Contains(lower, "[remote rejected]"):
+		return RejectionOther, true
+	}
+	return "", false
+}
+
+// PullRebase fetches branch from remote and rebases the current branch onto it
+func PullRebase(remote, branch string) error {
+	_, err := PullWithOptions(PullOptions{Remote: remote, Branch: branch, Mode: PullModeRebase})
+	return err
+}
+
+// MoveCommitsToNewBranch creates newBranch at HEAD and switches to it, then
+// resets the previously checked out branch to its upstream so unpushed
+// commits only remain on newBranch
+func MoveCommitsToNewBranch(newBranch string) error {
+	branch, err := CurrentBranch()
+	if err != nil {
+		return err
+	}
+	remote, remoteBranch, ok := Upstream(branch)
+	if !ok {
+		return fmt.Errorf("branch %s has no upstream", branch)
+	}
+
+	if output, err := RunCommand(fmt.Sprintf("git checkout -b %s", quoteArg(newBranch))); err != nil {
+		return fmt.Errorf("failed to create branch %s: %s", newBranch, output)
+	}
+	upstreamRef := fmt.Sprintf("refs/remotes/%s/%s", remote, remoteBranch)
+	if output, err := RunCommand(fmt.Sprintf("git branch -f %s %s", quoteArg(branch), quoteArg(upstreamRef))); err != nil {
+		return fmt.Errorf("failed to reset %s to %s/%s: %s", branch, remote, remoteBranch, output)
+	}
+	return nil
+}
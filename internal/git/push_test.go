@@ -0,0 +1,138 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"testing"
+)
+
+func TestPushCurrentBranch(t *testing.T) {
+	// Set up test repository
+	_, cleanup := setupTestRepo(t)
+	defer cleanup()
+	remoteDir, cleanupRemote := setupTestRemote(t, "origin")
+	defer cleanupRemote()
+
+	createTestFile(t, "test.txt", "first")
+	createTestCommit(t, "First commit")
+
+	branch, err := CurrentBranch()
+	if err != nil {
+		t.Fatalf("CurrentBranch() error = %v", err)
+	}
+	if _, _, ok := Upstream(branch); ok {
+		t.Fatal("Upstream() reported an upstream before the first push")
+	}
+
+	result, err := PushCurrentBranch()
+	if err != nil {
+		t.Fatalf("PushCurrentBranch() error = %v", err)
+	}
+	if !result.SetUpstream || result.Remote != "origin" {
+		t.Errorf("first push = %+v, want upstream set on origin", result)
+	}
+	remote, remoteBranch, ok := Upstream(branch)
+	if !ok || remote != "origin" || remoteBranch != branch {
+		t.Errorf("Upstream() = %q, %q, %v, want origin/%s", remote, remoteBranch, ok, branch)
+	}
+
+	createTestFile(t, "test.txt", "second")
+	createTestCommit(t, "Second commit")
+	result, err = PushCurrentBranch()
+	if err != nil {
+		t.Fatalf("PushCurrentBranch() error = %v", err)
+	}
+	if result.SetUpstream {
+		t.Error("second push should use the existing upstream")
+	}
+
+	// Advance the remote from another clone so the next push is rejected
+	otherDir, err := os.MkdirTemp("", "git-tool-clone-*")
+	if err != nil {
+		t.Fatalf("Failed to create clone directory: %v", err)
+	}
+	defer os.RemoveAll(otherDir)
+	script := "git clone -q " + quoteArg(remoteDir) + " " + quoteArg(otherDir) + " && cd " + quoteArg(otherDir) +
+		" && git -c user.name=Other -c user.email=other@example.com commit -q --allow-empty -m Other && git push -q"
+	if output, err := exec.Command("sh", "-c", script).CombinedOutput(); err != nil {
+		t.Fatalf("Failed to advance remote: %v: %s", err, output)
+	}
+
+	createTestFile(t, "test.txt", "third")
+	createTestCommit(t, "Third commit")
+	_, err = PushCurrentBranch()
+	var rejected *PushRejectedError
+	if !errors.As(err, &rejected) || rejected.Reason != RejectionNonFastForward {
+		t.Fatalf("PushCurrentBranch() error = %v, want non-fast-forward rejection", err)
+	}
+
+	if err := PullRebase("origin", branch); err != nil {
+		t.Fatalf("PullRebase() error = %v", err)
+	}
+	if _, err := PushCurrentBranch(); err != nil {
+		t.Errorf("PushCurrentBranch() after rebase error = %v", err)
+	}
+}
+
+func TestClassifyPushRejection(t *testing.T) {
+	tests := []struct {
+		output   string
+		want     PushRejection
+		rejected bool
+	}{
+		{"!\trefs/heads/main:refs/heads/main\t[rejected] (fetch first)", RejectionNonFastForward, true},
+		{"!\trefs/heads/main:refs/heads/main\t[rejected] (non-fast-forward)", RejectionNonFastForward, true},
+		{"!\trefs/heads/main:refs/heads/main\t[rejected] (stale info)", RejectionStaleLease, true},
+		{"remote: error: GH006: Protected branch update failed for refs/heads/main.\n!\t[remote rejected] (protected branch hook declined)", RejectionProtectedBranch, true},
+		{"!\trefs/heads/main:refs/heads/main\t[remote rejected] (pre-receive hook declined)", RejectionOther, true},
+		{"fatal: unable to access repository", "", false},
+	}
+
+	for _, tt := range tests {
+		got, rejected := classifyPushRejection(tt.output)
+		if got != tt.want || rejected != tt.rejected {
+			t.Errorf("classifyPushRejection(%q) = %q, %v, want %q, %v", tt.output, got, rejected, tt.want, tt.rejected)
+		}
+	}
+}
+
+func TestPushForceWithLease(t *testing.T) {
+	// Set up test repository
+	_, cleanup := setupTestRepo(t)
+	defer cleanup()
+	_, cleanupRemote := setupTestRemote(t, "origin")
+	defer cleanupRemote()
+
+	createTestFile(t, "test.txt", "first")
+	createTestCommit(t, "First commit")
+	if _, err := PushCurrentBranch(); err != nil {
+		t.Fatalf("PushCurrentBranch() error = %v", err)
+	}
+
+	if err := AmendCommit("First commit, amended"); err != nil {
+		t.Fatalf("AmendCommit() error = %v", err)
+	}
+	opts, err := CurrentPushOptions()
+	if err != nil {
+		t.Fatalf("CurrentPushOptions() error = %v", err)
+	}
+	if opts.SetUpstream {
+		t.Error("CurrentPushOptions() should use the existing upstream")
+	}
+
+	var rejected *PushRejectedError
+	if _, err := PushBranch(opts); !errors.As(err, &rejected) {
+		t.Fatalf("PushBranch() error = %v, want rejection of rewritten history", err)
+	}
+
+	opts.ForceWithLease = true
+	if _, err := PushBranch(opts); err != nil {
+		t.Fatalf("PushBranch() with lease error = %v", err)
+	}
+	local, _ := RunCommand("git rev-parse HEAD")
+	remote, _ := RunCommand("git rev-parse " + opts.Remote + "/" + opts.RemoteRef)
+	if local != remote {
+		t.Errorf("remote = %s, want %s", remote, local)
+	}
+}
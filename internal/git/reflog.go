@@ -0,0 +1,127 @@
+/*
+ * GitHubber - Git Reflog Operations
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Typed reflog entries and recovery of branches from the reflog
+ */
+
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReflogEntry is a single update of a ref recorded in its reflog
+type ReflogEntry struct {
+	Index    int
+	Selector string // e.g. HEAD@{2}
+	OldHash  string // Empty for the first entry of a reflog
+	NewHash  string
+	Action   string // e.g. "commit", "checkout", "reset", "commit (amend)"
+	Message  string
+	Date     time.Time
+}
+
+// ListReflog returns up to n reflog entries of ref (HEAD or a branch name),
+// newest first. n <= 0 returns all entries.
+func ListReflog(ref string, n int) ([]ReflogEntry, error) {
+	limit := ""
+	if n > 0 {
+		// One extra entry supplies the old hash of the last returned entry
+		limit = fmt.Sprintf(" -n %d", n+1)
+	}
+	output, err := RunCommand(fmt.Sprintf("git log -g --date=unix --format=%s%s %s --",
+		quoteArg("%gd%x1f%H%x1f%gs"), limit, quoteArg(ref)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reflog of %s: %s", ref, output)
+	}
+
+	entries, err := parseReflog(ref, output)
+	if err != nil {
+		return nil, err
+	}
+	if n > 0 && len(entries) > n {
+		entries = entries[:n]
+	}
+	return entries, nil
+}
+
+// parseReflog parses git log -g output produced by ListReflog. Each entry's
+// old hash is the new hash of the entry recorded before it.
+func parseReflog(ref, output string) ([]ReflogEntry, error) {
+	var entries []ReflogEntry
+	for _, line := range strings.Split(output, "\n") {
+		if line == "" {
+			continue
+		}
+		fields := strings.SplitN(line, "\x1f", 3)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("unexpected reflog entry: %q", line)
+		}
+
+		index := len(entries)
+		entry := ReflogEntry{
+			Index:    index,
+			Selector: fmt.Sprintf("%s@{%d}", ref, index),
+			NewHash:  fields[1],
+		}
+
+		// With --date=unix the selector carries the entry's timestamp
+		selector := fields[0]
+		if start := strings.LastIndex(selector, "@{"); start != -1 && strings.HasSuffix(selector, "}") {
+			if ts, err := strconv.ParseInt(selector[start+2:len(selector)-1], 10, 64); err == nil {
+				entry.Date = time.Unix(ts, 0)
+			}
+		}
+
+		entry.Action, entry.Message = fields[2], ""
+		if action, message, ok := strings.Cut(fields[2], ": "); ok {
+			entry.Action, entry.Message = action, message
+		}
+
+		if index > 0 {
+			entries[index-1].OldHash = entry.NewHash
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// ShowCommit returns the log message and diffstat of commit
+func ShowCommit(commit string) (string, error) {
+	output, err := RunCommand(fmt.Sprintf("git show --stat %s --", quoteArg(commit)))
+	if err != nil {
+		return "", fmt.Errorf("failed to show commit %s: %s", commit, output)
+	}
+	return output, nil
+}
+
+// RestoreBranch points branch at commit. The checked out branch is moved
+// with reset --keep, which refuses to overwrite uncommitted changes.
+func RestoreBranch(branch, commit string) error {
+	current, _ := CurrentBranch()
+
+	var command string
+	if branch == current {
+		command = fmt.Sprintf("git reset --keep %s", quoteArg(commit))
+	} else {
+		command = fmt.Sprintf("git branch -f %s %s", quoteArg(branch), quoteArg(commit))
+	}
+
+	output, err := RunCommand(command)
+	if err != nil {
+		return fmt.Errorf("failed to restore %s: %s", branch, output)
+	}
+	return nil
+}
+
+// CreateBranchAt creates branch name at commit without switching to it
+func CreateBranchAt(name, commit string) error {
+	output, err := RunCommand(fmt.Sprintf("git branch %s %s", quoteArg(name), quoteArg(commit)))
+	if err != nil {
+		return fmt.Errorf("failed to create branch %s: %s", name, output)
+	}
+	return nil
+}
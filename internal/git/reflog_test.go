@@ -0,0 +1,78 @@
+package git
+
+import (
+	"testing"
+)
+
+func TestParseReflog(t *testing.T) {
+	output := "HEAD@{1700000200}\x1fccc\x1freset: moving to HEAD~1\n" +
+		"HEAD@{1700000100}\x1fbbb\x1fcommit: Add feature\n" +
+		"HEAD@{1700000000}\x1faaa\x1fcommit (initial): Initial commit"
+
+	entries, err := parseReflog("HEAD", output)
+	if err != nil {
+		t.Fatalf("parseReflog() error = %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("parseReflog() returned %d entries, want 3", len(entries))
+	}
+
+	first := entries[0]
+	if first.Selector != "HEAD@{0}" || first.OldHash != "bbb" || first.NewHash != "ccc" ||
+		first.Action != "reset" || first.Message != "moving to HEAD~1" || first.Date.Unix() != 1700000200 {
+		t.Errorf("entries[0] = %+v", first)
+	}
+	if entries[2].Action != "commit (initial)" || entries[2].OldHash != "" {
+		t.Errorf("entries[2] = %+v, want initial commit without old hash", entries[2])
+	}
+}
+
+func TestReflogRecovery(t *testing.T) {
+	// Set up test repository
+	_, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	createTestFile(t, "test.txt", "initial")
+	createTestCommit(t, "Initial commit")
+	createTestFile(t, "test.txt", "lost")
+	createTestCommit(t, "Lost commit")
+	lost, _ := RunCommand("git rev-parse HEAD")
+
+	if _, err := RunCommand("git reset --hard HEAD~1"); err != nil {
+		t.Fatalf("Failed to reset: %v", err)
+	}
+
+	entries, err := ListReflog("HEAD", 2)
+	if err != nil {
+		t.Fatalf("ListReflog() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("ListReflog() returned %d entries, want 2", len(entries))
+	}
+	if entries[0].Action != "reset" || entries[0].OldHash != lost {
+		t.Errorf("entries[0] = %+v, want reset from the lost commit", entries[0])
+	}
+	if entries[1].NewHash != lost || entries[1].OldHash == "" {
+		t.Errorf("entries[1] = %+v, want the lost commit with its old hash", entries[1])
+	}
+
+	if err := CreateBranchAt("rescued", entries[1].NewHash); err != nil {
+		t.Fatalf("CreateBranchAt() error = %v", err)
+	}
+	if head, _ := RunCommand("git rev-parse rescued"); head != lost {
+		t.Errorf("rescued = %s, want %s", head, lost)
+	}
+
+	branch, _ := CurrentBranch()
+	if err := RestoreBranch(branch, entries[1].NewHash); err != nil {
+		t.Fatalf("RestoreBranch() error = %v", err)
+	}
+	assertFileContent(t, "test.txt", "lost")
+
+	if err := RestoreBranch("rescued", "HEAD~1"); err != nil {
+		t.Fatalf("RestoreBranch() on another branch error = %v", err)
+	}
+	if show, err := ShowCommit("rescued"); err != nil || show == "" {
+		t.Errorf("ShowCommit() = %q, %v", show, err)
+	}
+}
@@ -0,0 +1,334 @@
+/*
+ * GitHubber - Release Management
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Semantic version detection, bump analysis and release tagging
+ */
+
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version as used in release tags
+type Version struct {
+	Prefix     string // Optional tag prefix such as "v"
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+}
+
+// BumpType is the kind of version increment a set of changes requires
+type BumpType int
+
+const (
+	BumpNone BumpType = iota
+	BumpPatch
+	BumpMinor
+	BumpMajor
+)
+
+// String returns the lower-case name of the bump
+func (b BumpType) String() string {
+	switch b {
+	case BumpPatch:
+		return "patch"
+	case BumpMinor:
+		return "minor"
+	case BumpMajor:
+		return "major"
+	default:
+		return "none"
+	}
+}
+
+// ParseBumpType parses "major", "minor" or "patch"
+func ParseBumpType(s string) (BumpType, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "major":
+		return BumpMajor, nil
+	case "minor":
+		return BumpMinor, nil
+	case "patch":
+		return BumpPatch, nil
+	default:
+		return BumpNone, fmt.Errorf("invalid bump type: %s (valid types: major, minor, patch)", s)
+	}
+}
+
+var semverPattern = regexp.MustCompile(`^(v?)(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// ParseVersion parses a semantic version with an optional "v" prefix
+func ParseVersion(s string) (Version, error) {
+	match := semverPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return Version{}, fmt.Errorf("not a semantic version: %s", s)
+	}
+
+	major, _ := strconv.Atoi(match[2])
+	minor, _ := strconv.Atoi(match[3])
+	patch, _ := strconv.Atoi(match[4])
+
+	return Version{
+		Prefix:     match[1],
+		Major:      major,
+		Minor:      minor,
+		Patch:      patch,
+		Prerelease: match[5],
+	}, nil
+}
+
+// String formats the version including its prefix
+func (v Version) String() string {
+	s := fmt.Sprintf("%s%d.%d.%d", v.Prefix, v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 depending on whether v has lower, equal or
+// higher precedence than other, following the semver specification
+func (v Version) Compare(other Version) int {
+	for _, pair := range [][2]int{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if pair[0] != pair[1] {
+			if pair[0] < pair[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+// comparePrerelease compares pre-release identifiers; a version without
+// a pre-release has higher precedence than one with
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if an != bn {
+				if an < bn {
+					return -1
+				}
+				return 1
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+
+	switch {
+	case len(as) < len(bs):
+		return -1
+	case len(as) > len(bs):
+		return 1
+	}
+	return 0
+}
+
+// Bump returns the version incremented by bump with any pre-release removed
+func (v Version) Bump(bump BumpType) Version {
+	next := Version{Prefix: v.Prefix, Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	switch bump {
+	case BumpMajor:
+		next.Major++
+		next.Minor, next.Patch = 0, 0
+	case BumpMinor:
+		next.Minor++
+		next.Patch = 0
+	case BumpPatch:
+		next.Patch++
+	}
+	return next
+}
+
+// DetermineBump proposes a version bump for a set of commits: breaking
+// changes require a major bump, features a minor one and anything else a patch
+func DetermineBump(commits []CommitDetail) BumpType {
+	bump := BumpNone
+	for _, commit := range commits {
+		parsed, ok := ParseConventionalCommit(commit.Subject, commit.Body)
+		switch {
+		case parsed.Breaking:
+			return BumpMajor
+		case ok && parsed.Type == "feat":
+			bump = BumpMinor
+		case bump == BumpNone:
+			bump = BumpPatch
+		}
+	}
+	return bump
+}
+
+// LatestVersionTag returns the tag holding the highest semantic version.
+// Pre-release tags are skipped unless includePrerelease is set.
+func LatestVersionTag(tags []Tag, includePrerelease bool) (*Tag, Version, bool) {
+	var latest *Tag
+	var latestVersion Version
+	for i := range tags {
+		version, err := ParseVersion(tags[i].Name)
+		if err != nil || (version.Prerelease != "" && !includePrerelease) {
+			continue
+		}
+		if latest == nil || version.Compare(latestVersion) > 0 {
+			latest = &tags[i]
+			latestVersion = version
+		}
+	}
+	return latest, latestVersion, latest != nil
+}
+
+// ReleasePlan describes the next release proposed from the commit history
+type ReleasePlan struct {
+	PreviousTag string // Latest stable release tag, empty for a first release
+	Previous    Version
+	Bump        BumpType
+	Next        Version
+	Commits     []CommitDetail
+}
+
+// PlanRelease analyzes the commits since the latest stable release tag
+// and proposes the next version. A non-empty prerelease identifier such
+// as "rc" produces the next free pre-release of the proposed version.
+func PlanRelease(prerelease string) (*ReleasePlan, error) {
+	tags, err := ListTags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	plan := &ReleasePlan{Previous: Version{Prefix: "v"}}
+	if tag, version, ok := LatestVersionTag(tags, false); ok {
+		plan.PreviousTag = tag.Name
+		plan.Previous = version
+	}
+
+	plan.Commits, err = CommitsInRange(plan.PreviousTag, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	if len(plan.Commits) == 0 {
+		return nil, fmt.Errorf("no commits since %s", plan.PreviousTag)
+	}
+
+	plan.Bump = DetermineBump(plan.Commits)
+	plan.SetBump(plan.Bump, prerelease, tags)
+
+	return plan, nil
+}
+
+// SetBump recomputes the next version for bump, choosing the next free
+// pre-release number among tags when prerelease is set
+func (p *ReleasePlan) SetBump(bump BumpType, prerelease string, tags []Tag) {
+	p.Bump = bump
+	p.Next = p.Previous.Bump(bump)
+	if prerelease == "" {
+		return
+	}
+
+	number := 1
+	for _, tag := range tags {
+		version, err := ParseVersion(tag.Name)
+		if err != nil || version.Prerelease == "" || version.Bump(BumpNone) != p.Next {
+			continue
+		}
+		id, n, ok := strings.Cut(version.Prerelease, ".")
+		if !ok || id != prerelease {
+			continue
+		}
+		if value, err := strconv.Atoi(n); err == nil && value >= number {
+			number = value + 1
+		}
+	}
+	p.Next.Prerelease = fmt.Sprintf("%s.%d", prerelease, number)
+}
+
+// TagMessage generates the annotated tag message for the release,
+// grouping commits by their Conventional Commits type
+func (p *ReleasePlan) TagMessage() string {
+	var breaking []string
+	sections := make(map[string][]string)
+	var order []string
+
+	for _, commit := range p.Commits {
+		parsed, ok := ParseConventionalCommit(commit.Subject, commit.Body)
+		title := "Other Changes"
+		line := commit.Subject
+		if ok {
+			title = CommitTypeTitle(parsed.Type)
+			line = parsed.Description
+			if parsed.Scope != "" {
+				line = parsed.Scope + ": " + line
+			}
+		}
+		line = fmt.Sprintf("- %s (%s)", line, commit.ShortHash())
+
+		if parsed.Breaking {
+			breaking = append(breaking, fmt.Sprintf("- %s (%s)", parsed.BreakingNote, commit.ShortHash()))
+		}
+		if _, seen := sections[title]; !seen {
+			order = append(order, title)
+		}
+		sections[title] = append(sections[title], line)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Release %s\n", p.Next)
+	if len(breaking) > 0 {
+		fmt.Fprintf(&b, "\nBreaking Changes\n%s\n", strings.Join(breaking, "\n"))
+	}
+	for _, title := range sortedSectionTitles(order) {
+		fmt.Fprintf(&b, "\n%s\n%s\n", title, strings.Join(sections[title], "\n"))
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// sortedSectionTitles orders section titles by commitTypeTitles, with
+// "Other Changes" last
+func sortedSectionTitles(titles []string) []string {
+	present := make(map[string]bool, len(titles))
+	for _, title := range titles {
+		present[title] = true
+	}
+
+	var sorted []string
+	for _, t := range commitTypeTitles {
+		if present[t.Title] {
+			sorted = append(sorted, t.Title)
+		}
+	}
+	if present["Other Changes"] {
+		sorted = append(sorted, "Other Changes")
+	}
+	return sorted
+}
+
+// CreateReleaseTag creates the annotated tag for the planned release at HEAD
+func CreateReleaseTag(plan *ReleasePlan) error {
+	return CreateTagWithOptions(plan.Next.String(), TagOptions{
+		Message:   plan.TagMessage(),
+		Annotated: true,
+	})
+}
@@ -0,0 +1,150 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "v1.2.3", want: "v1.2.3"},
+		{input: "1.2.3", want: "1.2.3"},
+		{input: "v2.0.0-rc.1", want: "v2.0.0-rc.1"},
+		{input: "v1.0.0+build.5", want: "v1.0.0"},
+		{input: "v1.2", wantErr: true},
+		{input: "release-1", wantErr: true},
+		{input: "v01.2.3", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseVersion(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("ParseVersion() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	// Ordered by increasing precedence as in the semver specification
+	ordered := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta",
+		"1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.1.0", "2.0.0",
+	}
+
+	for i := 0; i < len(ordered)-1; i++ {
+		a, _ := ParseVersion(ordered[i])
+		b, _ := ParseVersion(ordered[i+1])
+		if a.Compare(b) != -1 || b.Compare(a) != 1 {
+			t.Errorf("expected %s < %s", ordered[i], ordered[i+1])
+		}
+		if a.Compare(a) != 0 {
+			t.Errorf("expected %s == %s", ordered[i], ordered[i])
+		}
+	}
+}
+
+func TestDetermineBump(t *testing.T) {
+	tests := []struct {
+		name     string
+		subjects []string
+		bodies   []string
+		want     BumpType
+	}{
+		{name: "fixes only", subjects: []string{"fix: a", "chore: b"}, want: BumpPatch},
+		{name: "feature", subjects: []string{"fix: a", "feat(ui): b"}, want: BumpMinor},
+		{name: "bang", subjects: []string{"feat!: drop api"}, want: BumpMajor},
+		{name: "footer", subjects: []string{"refactor: x"}, bodies: []string{"BREAKING CHANGE: config moved"}, want: BumpMajor},
+		{name: "non conventional", subjects: []string{"Update readme"}, want: BumpPatch},
+		{name: "empty", want: BumpNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var commits []CommitDetail
+			for i, subject := range tt.subjects {
+				commit := CommitDetail{Subject: subject}
+				if i < len(tt.bodies) {
+					commit.Body = tt.bodies[i]
+				}
+				commits = append(commits, commit)
+			}
+			if got := DetermineBump(commits); got != tt.want {
+				t.Errorf("DetermineBump() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanRelease(t *testing.T) {
+	// Set up test repository
+	_, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	createTestFile(t, "a.txt", "a")
+	createTestCommit(t, "chore: initial commit")
+	if err := CreateTag("v1.2.3", "Release v1.2.3"); err != nil {
+		t.Fatalf("CreateTag() error = %v", err)
+	}
+
+	createTestFile(t, "b.txt", "b")
+	createTestCommit(t, "feat(cli): add release command")
+	createTestFile(t, "c.txt", "c")
+	createTestCommit(t, "fix: handle empty tags")
+
+	plan, err := PlanRelease("")
+	if err != nil {
+		t.Fatalf("PlanRelease() error = %v", err)
+	}
+	if plan.PreviousTag != "v1.2.3" {
+		t.Errorf("PreviousTag = %s, want v1.2.3", plan.PreviousTag)
+	}
+	if plan.Bump != BumpMinor || plan.Next.String() != "v1.3.0" {
+		t.Errorf("plan = %s -> %s, want minor -> v1.3.0", plan.Bump, plan.Next)
+	}
+	if len(plan.Commits) != 2 {
+		t.Errorf("plan has %d commits, want 2", len(plan.Commits))
+	}
+
+	message := plan.TagMessage()
+	for _, want := range []string{"Release v1.3.0", "Features\n- cli: add release command", "Bug Fixes\n- handle empty tags"} {
+		if !strings.Contains(message, want) {
+			t.Errorf("TagMessage() = %q, want it to contain %q", message, want)
+		}
+	}
+
+	// Pre-releases count up from the existing ones
+	plan, err = PlanRelease("rc")
+	if err != nil {
+		t.Fatalf("PlanRelease() error = %v", err)
+	}
+	if plan.Next.String() != "v1.3.0-rc.1" {
+		t.Errorf("Next = %s, want v1.3.0-rc.1", plan.Next)
+	}
+	if err := CreateReleaseTag(plan); err != nil {
+		t.Fatalf("CreateReleaseTag() error = %v", err)
+	}
+	plan, err = PlanRelease("rc")
+	if err != nil {
+		t.Fatalf("PlanRelease() error = %v", err)
+	}
+	if plan.Next.String() != "v1.3.0-rc.2" {
+		t.Errorf("Next = %s, want v1.3.0-rc.2", plan.Next)
+	}
+
+	// The release tag is annotated and carries the generated notes
+	tags, _ := ListTags()
+	for _, tag := range tags {
+		if tag.Name == "v1.3.0-rc.1" && (!tag.Annotated || !strings.HasPrefix(tag.Message, "Release v1.3.0-rc.1")) {
+			t.Errorf("release tag = %+v, want annotated with release notes", tag)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+/*
+ * GitHubber - Git Remote Operations
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Typed remote listing and remote management
+ */
+
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Remote describes a configured remote
+type Remote struct {
+	Name     string
+	FetchURL string
+	PushURL  string
+	Branches []string // Remote-tracking branches, without the remote prefix
+}
+
+// ListRemotes returns all configured remotes in configuration order
+func ListRemotes() ([]Remote, error) {
+	output, err := RunCommand("git remote -v")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remotes: %s", output)
+	}
+	remotes := parseRemotes(output)
+
+	refs, err := RunCommand(fmt.Sprintf("git for-each-ref refs/remotes --format=%s", quoteArg("%(refname)%1f%(symref)")))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remote branches: %s", refs)
+	}
+	for _, line := range strings.Split(refs, "\n") {
+		ref, symref, _ := strings.Cut(line, "\x1f")
+		if symref != "" {
+			// Symbolic refs such as <remote>/HEAD are not branches
+			continue
+		}
+		// Remote names may contain slashes, so the longest matching name wins
+		owner, branch := -1, ""
+		for i, remote := range remotes {
+			rest, ok := strings.CutPrefix(ref, "refs/remotes/"+remote.Name+"/")
+			if ok && (owner < 0 || len(remote.Name) > len(remotes[owner].Name)) {
+				owner, branch = i, rest
+			}
+		}
+		if owner >= 0 {
+			remotes[owner].Branches = append(remotes[owner].Branches, branch)
+		}
+	}
+
+	return remotes, nil
+}
+
+// parseRemotes parses the output of git remote -v
+func parseRemotes(output string) []Remote {
+	var remotes []Remote
+	index := make(map[string]int)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+		name, url, kind := fields[0], fields[1], fields[2]
+
+		i, ok := index[name]
+		if !ok {
+			i = len(remotes)
+			index[name] = i
+			remotes = append(remotes, Remote{Name: name})
+		}
+		switch kind {
+		case "(fetch)":
+			remotes[i].FetchURL = url
+		case "(push)":
+			remotes[i].PushURL = url
+		}
+	}
+	return remotes
+}
+
+// RemoteNames returns the names of all configured remotes
+func RemoteNames() ([]string, error) {
+	output, err := RunCommand("git remote")
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(output), nil
+}
+
+// HasRemote reports whether a remote called name is configured
+func HasRemote(name string) bool {
+	names, err := RemoteNames()
+	if err != nil {
+		return false
+	}
+	return containsString(names, name)
+}
+
+// DefaultRemote returns "origin" when it exists, otherwise the first
+// configured remote
+func DefaultRemote() (string, error) {
+	remotes, err := RemoteNames()
+	if err != nil {
+		return "", err
+	}
+	if len(remotes) == 0 {
+		return "", fmt.Errorf("no remotes configured")
+	}
+	if containsString(remotes, "origin") {
+		return "origin", nil
+	}
+	return remotes[0], nil
+}
+
+// CurrentRemote returns the remote tracked by the current branch, falling
+// back to DefaultRemote
+func CurrentRemote() (string, error) {
+	if branch, err := CurrentBranch(); err == nil {
+		if remote, _, ok := Upstream(branch); ok {
+			return remote, nil
+		}
+	}
+	return DefaultRemote()
+}
+
+// RemoteURL returns the fetch URL of remote
+func RemoteURL(name string) (string, error) {
+	url, err := RunCommand(fmt.Sprintf("git remote get-url %s", quoteArg(name)))
+	if err != nil {
+		return "", fmt.Errorf("failed to get URL of remote %s: %s", name, url)
+	}
+	return url, nil
+}
+
+// AddRemote adds a remote called name pointing at url
+func AddRemote(name, url string) error {
+	output, err := RunCommand(fmt.Sprintf("git remote add %s %s", quoteArg(name), quoteArg(url)))
+	if err != nil {
+		return fmt.Errorf("failed to add remote %s: %s", name, output)
+	}
+	return nil
+}
+
+// RemoveRemote removes a remote and its remote-tracking branches
+func RemoveRemote(name string) error {
+	output, err := RunCommand(fmt.Sprintf("git remote remove %s", quoteArg(name)))
+	if err != nil {
+		return fmt.Errorf("failed to remove remote %s: %s", name, output)
+	}
+	return nil
+}
+
+// RenameRemote renames a remote, updating its remote-tracking branches
+func RenameRemote(oldName, newName string) error {
+	output, err := RunCommand(fmt.Sprintf("git remote rename %s %s", quoteArg(oldName), quoteArg(newName)))
+	if err != nil {
+		return fmt.Errorf("failed to rename remote %s: %s", oldName, output)
+	}
+	return nil
+}
+
+// SetRemoteURL changes the fetch URL of a remote, or only its push URL
+// when push is true
+func SetRemoteURL(name, url string, push bool) error {
+	command := "git remote set-url"
+	if push {
+		command += " --push"
+	}
+	output, err := RunCommand(fmt.Sprintf("%s %s %s", command, quoteArg(name), quoteArg(url)))
+	if err != nil {
+		return fmt.Errorf("failed to set URL of remote %s: %s", name, output)
+	}
+	return nil
+}
+
+// BaseRemote returns the remote changes are ultimately merged into. In fork
+// setups with an "upstream" remote that is upstream; otherwise it is
+// DefaultRemote.
+func BaseRemote() (string, error) {
+	if HasRemote("upstream") {
+		return "upstream", nil
+	}
+	return DefaultRemote()
+}
@@ -0,0 +1,113 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRemotes(t *testing.T) {
+	output := "origin\tgit@github.com:me/repo.git (fetch)\n" +
+		"origin\tgit@github.com:me/repo.git (push)\n" +
+		"upstream\thttps://github.com/them/repo.git (fetch)\n" +
+		"upstream\tno_push (push)"
+
+	want := []Remote{
+		{Name: "origin", FetchURL: "git@github.com:me/repo.git", PushURL: "git@github.com:me/repo.git"},
+		{Name: "upstream", FetchURL: "https://github.com/them/repo.git", PushURL: "no_push"},
+	}
+	if got := parseRemotes(output); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseRemotes() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRemoteManagement(t *testing.T) {
+	// Set up test repository
+	_, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	createTestFile(t, "test.txt", "test content")
+	createTestCommit(t, "Initial commit")
+
+	if _, err := DefaultRemote(); err == nil {
+		t.Error("DefaultRemote() should fail without remotes")
+	}
+
+	_, cleanupFork := setupTestRemote(t, "fork")
+	defer cleanupFork()
+	if err := AddRemote("upstream", "https://github.com/them/repo.git"); err != nil {
+		t.Fatalf("AddRemote() error = %v", err)
+	}
+	if remote, _ := DefaultRemote(); remote != "fork" {
+		t.Errorf("DefaultRemote() = %q, want first remote fork", remote)
+	}
+	if remote, _ := BaseRemote(); remote != "upstream" {
+		t.Errorf("BaseRemote() = %q, want upstream", remote)
+	}
+
+	if err := RenameRemote("fork", "origin"); err != nil {
+		t.Fatalf("RenameRemote() error = %v", err)
+	}
+	if _, err := PushCurrentBranch(); err != nil {
+		t.Fatalf("PushCurrentBranch() error = %v", err)
+	}
+	if remote, _ := CurrentRemote(); remote != "origin" {
+		t.Errorf("CurrentRemote() = %q, want origin", remote)
+	}
+	info, err := GetRepositoryInfo()
+	if err != nil || info.Remote != "origin" {
+		t.Errorf("GetRepositoryInfo() = %+v, %v, want remote origin", info, err)
+	}
+
+	if err := SetRemoteURL("upstream", "https://github.com/them/push.git", true); err != nil {
+		t.Fatalf("SetRemoteURL() error = %v", err)
+	}
+
+	// Only symbolic refs are skipped, and a remote whose name extends
+	// another's keeps its own branches
+	branch, _ := CurrentBranch()
+	if _, err := RunCommand("git push -q origin HEAD:fix/HEAD-detach"); err != nil {
+		t.Fatalf("Failed to push: %v", err)
+	}
+	if err := SetRemoteDefaultBranch("origin", branch); err != nil {
+		t.Fatalf("SetRemoteDefaultBranch() error = %v", err)
+	}
+	if err := AddRemote("origin/fork", "https://github.com/fork/repo.git"); err != nil {
+		t.Fatalf("AddRemote() error = %v", err)
+	}
+	if _, err := RunCommand("git update-ref refs/remotes/origin/fork/feature HEAD"); err != nil {
+		t.Fatalf("Failed to create remote-tracking branch: %v", err)
+	}
+
+	remotes, err := ListRemotes()
+	if err != nil {
+		t.Fatalf("ListRemotes() error = %v", err)
+	}
+	if len(remotes) != 3 {
+		t.Fatalf("ListRemotes() returned %d remotes, want 3", len(remotes))
+	}
+	for _, remote := range remotes {
+		switch remote.Name {
+		case "origin":
+			if want := []string{"fix/HEAD-detach", branch}; !reflect.DeepEqual(remote.Branches, want) {
+				t.Errorf("origin branches = %v, want %v", remote.Branches, want)
+			}
+		case "origin/fork":
+			if !reflect.DeepEqual(remote.Branches, []string{"feature"}) {
+				t.Errorf("origin/fork branches = %v, want [feature]", remote.Branches)
+			}
+		case "upstream":
+			if remote.FetchURL != "https://github.com/them/repo.git" || remote.PushURL != "https://github.com/them/push.git" {
+				t.Errorf("upstream = %+v", remote)
+			}
+		default:
+			t.Errorf("unexpected remote %q", remote.Name)
+		}
+	}
+
+	if err := RemoveRemote("upstream"); err != nil {
+		t.Fatalf("RemoveRemote() error = %v", err)
+	}
+	if HasRemote("upstream") {
+		t.Error("upstream should have been removed")
+	}
+}
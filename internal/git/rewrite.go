@@ -0,0 +1,130 @@
+/*
+ * GitHubber - Git History Rewriting
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Amend, reword and undo operations for recent commits
+ */
+
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IsPushed reports whether commit is already contained in the upstream of
+// the current branch. Branches without an upstream report false.
+func IsPushed(commit string) (bool, error) {
+	branch, err := CurrentBranch()
+	if err != nil {
+		return false, err
+	}
+	remote, remoteBranch, ok := Upstream(branch)
+	if !ok {
+		return false, nil
+	}
+
+	upstreamRef := fmt.Sprintf("refs/remotes/%s/%s", remote, remoteBranch)
+	if _, err := RunCommand(fmt.Sprintf("git rev-parse --verify --quiet %s", quoteArg(upstreamRef))); err != nil {
+		return false, nil
+	}
+	_, err = RunCommand(fmt.Sprintf("git merge-base --is-ancestor %s %s", quoteArg(commit), quoteArg(upstreamRef)))
+	return err == nil, nil
+}
+
+// AmendCommit adds the staged changes to the last commit. An empty message
+// keeps the existing commit message.
+func AmendCommit(message string) error {
+	if message == "" {
+		output, err := RunCommand(fmt.Sprintf("%s commit --amend --no-edit%s", gitCommand(), signFlag()))
+		if err != nil {
+			return fmt.Errorf("failed to amend commit: %s", output)
+		}
+		return nil
+	}
+
+	path, cleanup, err := writeMessageFile(message)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	output, err := RunCommand(fmt.Sprintf("%s commit --amend%s --cleanup=whitespace -F %s", gitCommand(), signFlag(), quoteArg(path)))
+	if err != nil {
+		return fmt.Errorf("failed to amend commit: %s", output)
+	}
+	return nil
+}
+
+// RewordCommit replaces the message of commit, which must be reachable from
+// HEAD, without changing any tree. The commit is recreated with its original
+// author and date, and the commits after it are replayed on top.
+func RewordCommit(commit, message string) error {
+	hash, err := RunCommand(fmt.Sprintf("git rev-parse --verify --quiet %s", quoteArg(commit+"^{commit}")))
+	if err != nil {
+		return fmt.Errorf("invalid commit: %s", commit)
+	}
+	if _, err := RunCommand(fmt.Sprintf("git merge-base --is-ancestor %s HEAD", hash)); err != nil {
+		return fmt.Errorf("commit %s is not part of the current branch", commit)
+	}
+
+	path, cleanup, err := writeMessageFile(message)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	head, _ := RunCommand("git rev-parse HEAD")
+	if head == hash {
+		// --only ignores staged changes so only the message changes
+		output, err := RunCommand(fmt.Sprintf("%s commit --amend --only%s --cleanup=whitespace -F %s", gitCommand(), signFlag(), quoteArg(path)))
+		if err != nil {
+			return fmt.Errorf("failed to reword commit: %s", output)
+		}
+		return nil
+	}
+
+	if clean, err := IsWorkingDirectoryClean(); err != nil || !clean {
+		return fmt.Errorf("working directory must be clean before rewording older commits")
+	}
+
+	info, err := RunCommand(fmt.Sprintf("git log -1 --format=%s %s", quoteArg("%an%x1f%ae%x1f%aI%x1f%P"), hash))
+	if err != nil {
+		return fmt.Errorf("failed to read commit %s: %w", commit, err)
+	}
+	fields := strings.Split(info, "\x1f")
+	if len(fields) != 4 {
+		return fmt.Errorf("unexpected commit information: %q", info)
+	}
+
+	parents := ""
+	for _, parent := range strings.Fields(fields[3]) {
+		parents += " -p " + parent
+	}
+	env := fmt.Sprintf("GIT_AUTHOR_NAME=%s GIT_AUTHOR_EMAIL=%s GIT_AUTHOR_DATE=%s",
+		quoteArg(fields[0]), quoteArg(fields[1]), quoteArg(fields[2]))
+	reworded, err := RunCommand(fmt.Sprintf("%s %s commit-tree%s %s%s -F %s",
+		env, gitCommand(), signFlag(), quoteArg(hash+"^{tree}"), parents, quoteArg(path)))
+	if err != nil {
+		return fmt.Errorf("failed to reword commit: %s", reworded)
+	}
+
+	output, err := RunCommand(fmt.Sprintf("%s rebase --rebase-merges%s --onto %s %s", gitCommand(), signFlag(), reworded, hash))
+	if err != nil {
+		RunCommand("git rebase --abort")
+		return fmt.Errorf("failed to replay commits after %s: %s", commit, output)
+	}
+	return nil
+}
+
+// UndoLastCommit removes the last commit from the branch, keeping its
+// changes staged
+func UndoLastCommit() error {
+	if _, err := RunCommand("git rev-parse --verify --quiet HEAD~1"); err != nil {
+		return fmt.Errorf("cannot undo the initial commit")
+	}
+	output, err := RunCommand("git reset --soft HEAD~1")
+	if err != nil {
+		return fmt.Errorf("failed to undo last commit: %s", output)
+	}
+	return nil
+}
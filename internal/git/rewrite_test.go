@@ -0,0 +1,99 @@
+package git
+
+import (
+	"testing"
+)
+
+func TestAmendAndUndo(t *testing.T) {
+	// Set up test repository
+	_, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	createTestFile(t, "test.txt", "initial")
+	createTestCommit(t, "Initial commit")
+	createTestFile(t, "test.txt", "second")
+	createTestCommit(t, "Second commit")
+
+	createTestFile(t, "extra.txt", "extra")
+	AddFiles("extra.txt")
+	if err := AmendCommit(""); err != nil {
+		t.Fatalf("AmendCommit() error = %v", err)
+	}
+	if files, _ := RunCommand("git show --name-only --format= HEAD"); files != "extra.txt\ntest.txt" {
+		t.Errorf("amended commit files = %q, want extra.txt and test.txt", files)
+	}
+	if subject, _ := RunCommand("git log -1 --format=%s"); subject != "Second commit" {
+		t.Errorf("subject = %q, amend without a message should keep it", subject)
+	}
+
+	if err := AmendCommit("Second commit, amended"); err != nil {
+		t.Fatalf("AmendCommit() error = %v", err)
+	}
+	if subject, _ := RunCommand("git log -1 --format=%s"); subject != "Second commit, amended" {
+		t.Errorf("subject = %q, want amended message", subject)
+	}
+
+	if err := UndoLastCommit(); err != nil {
+		t.Fatalf("UndoLastCommit() error = %v", err)
+	}
+	if subject, _ := RunCommand("git log -1 --format=%s"); subject != "Initial commit" {
+		t.Errorf("HEAD = %q, want Initial commit", subject)
+	}
+	if staged, _ := RunCommand("git diff --cached --name-only"); staged != "extra.txt\ntest.txt" {
+		t.Errorf("staged files = %q, changes should stay staged", staged)
+	}
+	if err := UndoLastCommit(); err == nil {
+		t.Error("UndoLastCommit() should refuse to undo the initial commit")
+	}
+}
+
+func TestRewordCommit(t *testing.T) {
+	// Set up test repository
+	_, cleanup := setupTestRepo(t)
+	defer cleanup()
+	_, cleanupRemote := setupTestRemote(t, "origin")
+	defer cleanupRemote()
+
+	createTestFile(t, "test.txt", "initial")
+	createTestCommit(t, "Initial commit")
+	if _, err := PushCurrentBranch(); err != nil {
+		t.Fatalf("PushCurrentBranch() error = %v", err)
+	}
+	createTestFile(t, "test.txt", "second")
+	createTestCommit(t, "Secnd commit")
+	createTestFile(t, "test.txt", "third")
+	createTestCommit(t, "Third commit")
+
+	target, _ := RunCommand("git rev-parse HEAD~1")
+	if pushed, err := IsPushed(target); err != nil || pushed {
+		t.Errorf("IsPushed(HEAD~1) = %v, %v, want false", pushed, err)
+	}
+	if pushed, err := IsPushed("HEAD~2"); err != nil || !pushed {
+		t.Errorf("IsPushed(HEAD~2) = %v, %v, want true", pushed, err)
+	}
+
+	treeBefore, _ := RunCommand("git rev-parse HEAD~1^{tree}")
+	authorBefore, _ := RunCommand("git log -1 --format=%an%ad HEAD~1")
+	if err := RewordCommit(target, "Second commit"); err != nil {
+		t.Fatalf("RewordCommit() error = %v", err)
+	}
+
+	subjects, _ := RunCommand("git log --format=%s")
+	if subjects != "Third commit\nSecond commit\nInitial commit" {
+		t.Errorf("history = %q", subjects)
+	}
+	if tree, _ := RunCommand("git rev-parse HEAD~1^{tree}"); tree != treeBefore {
+		t.Error("RewordCommit() changed the tree of the reworded commit")
+	}
+	if author, _ := RunCommand("git log -1 --format=%an%ad HEAD~1"); author != authorBefore {
+		t.Errorf("author = %q, want %q", author, authorBefore)
+	}
+	assertFileContent(t, "test.txt", "third")
+
+	if err := RewordCommit("HEAD", "Third commit, reworded"); err != nil {
+		t.Fatalf("RewordCommit(HEAD) error = %v", err)
+	}
+	if subject, _ := RunCommand("git log -1 --format=%s"); subject != "Third commit, reworded" {
+		t.Errorf("subject = %q", subject)
+	}
+}
@@ -0,0 +1,97 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigureSigning(t *testing.T) {
+	defer ConfigureSigning(SigningConfig{})
+
+	if err := ConfigureSigning(SigningConfig{Enabled: true, Format: "pgp"}); err == nil {
+		t.Error("ConfigureSigning() should reject unknown formats")
+	}
+	if err := ConfigureSigning(SigningConfig{Enabled: true}); err != nil {
+		t.Fatalf("ConfigureSigning() error = %v", err)
+	}
+	if signing.Format != "gpg" {
+		t.Errorf("Format = %q, want gpg by default", signing.Format)
+	}
+	if got := gitCommand(); got != "git -c gpg.format=openpgp" {
+		t.Errorf("gitCommand() = %q", got)
+	}
+}
+
+func TestSSHSigning(t *testing.T) {
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not available")
+	}
+
+	// Set up test repository
+	repoDir, cleanup := setupTestRepo(t)
+	defer cleanup()
+	defer ConfigureSigning(SigningConfig{})
+
+	keyPath := filepath.Join(repoDir, ".signing-key")
+	if output, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", "test", "-f", keyPath).CombinedOutput(); err != nil {
+		t.Fatalf("Failed to generate key: %v: %s", err, output)
+	}
+	publicKey, err := os.ReadFile(keyPath + ".pub")
+	if err != nil {
+		t.Fatalf("Failed to read public key: %v", err)
+	}
+	signersPath := filepath.Join(repoDir, ".allowed-signers")
+	createTestFile(t, signersPath, "test@example.com "+string(publicKey))
+	createTestFile(t, ".git/info/exclude", ".signing-key*\n.allowed-signers\n")
+
+	createTestFile(t, "test.txt", "unsigned")
+	createTestCommit(t, "Unsigned commit")
+
+	err = ConfigureSigning(SigningConfig{
+		Enabled:            true,
+		Format:             "ssh",
+		Key:                keyPath + ".pub",
+		AllowedSignersFile: signersPath,
+	})
+	if err != nil {
+		t.Fatalf("ConfigureSigning() error = %v", err)
+	}
+
+	createTestFile(t, "test.txt", "signed")
+	if err := AddFiles("test.txt"); err != nil {
+		t.Fatalf("AddFiles() error = %v", err)
+	}
+	if err := Commit("Signed commit"); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	if err := CreateTag("v1.0.0", "Signed release"); err != nil {
+		t.Fatalf("CreateTag() error = %v", err)
+	}
+
+	signatures, err := VerifyCommitSignatures(2)
+	if err != nil {
+		t.Fatalf("VerifyCommitSignatures() error = %v", err)
+	}
+	if len(signatures) != 2 {
+		t.Fatalf("VerifyCommitSignatures() returned %d entries, want 2", len(signatures))
+	}
+	if signatures[0].Status != SignatureGood || signatures[0].Signer != "test@example.com" {
+		t.Errorf("signed commit = %+v, want good signature by test@example.com", signatures[0])
+	}
+	if signatures[1].Status != SignatureNone {
+		t.Errorf("unsigned commit status = %s, want N", signatures[1].Status)
+	}
+
+	tags, err := ListTags()
+	if err != nil {
+		t.Fatalf("ListTags() error = %v", err)
+	}
+	if len(tags) != 1 || !tags[0].Signed || !tags[0].Annotated {
+		t.Errorf("ListTags() = %+v, want one signed annotated tag", tags)
+	}
+	if tags[0].Message != "Signed release" {
+		t.Errorf("tag message = %q, signature should not leak into the message", tags[0].Message)
+	}
+}
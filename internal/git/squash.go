@@ -79,8 +79,8 @@ sed -i '' -e '2,$s/pick/squash/' "$1"
         return fmt.Errorf("rebase failed: %w", err)
     }
 
-    // Set the final commit message
-    if _, err := RunCommand(fmt.Sprintf("git commit --amend -m \"%s\"", message)); err != nil {
+    // Set the final commit message, signing the result if enabled
+    if _, err := RunCommand(fmt.Sprintf("%s commit --amend%s -m %s", gitCommand(), signFlag(), quoteArg(message))); err != nil {
         return fmt.Errorf("failed to set commit message: %w", err)
     }
 
@@ -0,0 +1,181 @@
+/*
+ * GitHubber - Git Stash Operations
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Typed stash listing and per-entry stash management
+ */
+
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StashEntry describes a single entry of the stash list
+type StashEntry struct {
+	Index        int
+	Ref          string
+	Branch       string
+	Message      string
+	Date         time.Time
+	FilesChanged int
+}
+
+// StashOptions controls what StashPush records
+type StashOptions struct {
+	Message          string
+	Paths            []string
+	IncludeUntracked bool
+	KeepIndex        bool
+}
+
+// stashRef returns the reflog selector for the stash at index
+func stashRef(index int) string {
+	return fmt.Sprintf("stash@{%d}", index)
+}
+
+// ListStashes returns all stash entries, most recent first
+func ListStashes() ([]StashEntry, error) {
+	output, err := RunCommand("git stash list --format='%gd%x1f%gs%x1f%ct'")
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []StashEntry
+	for _, line := range strings.Split(output, "\n") {
+		if line == "" {
+			continue
+		}
+		entry, err := parseStashLine(line)
+		if err != nil {
+			return nil, err
+		}
+		if entry.FilesChanged, err = countStashFiles(entry.Ref); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// parseStashLine parses a line produced by the ListStashes format
+func parseStashLine(line string) (StashEntry, error) {
+	parts := strings.Split(line, "\x1f")
+	if len(parts) != 3 {
+		return StashEntry{}, fmt.Errorf("unexpected stash list line: %q", line)
+	}
+
+	entry := StashEntry{Ref: parts[0]}
+
+	index := strings.TrimSuffix(strings.TrimPrefix(parts[0], "stash@{"), "}")
+	n, err := strconv.Atoi(index)
+	if err != nil {
+		return StashEntry{}, fmt.Errorf("unexpected stash ref %q", parts[0])
+	}
+	entry.Index = n
+
+	// The subject is either "WIP on <branch>: <hash> <subject>" for
+	// stashes without a message or "On <branch>: <message>"
+	subject := parts[1]
+	wip := strings.HasPrefix(subject, "WIP on ")
+	subject = strings.TrimPrefix(strings.TrimPrefix(subject, "WIP on "), "On ")
+	if branch, message, ok := strings.Cut(subject, ": "); ok {
+		entry.Branch = branch
+		entry.Message = message
+	} else {
+		entry.Message = subject
+	}
+	if wip {
+		entry.Message = "WIP: " + entry.Message
+	}
+
+	if ts, err := strconv.ParseInt(parts[2], 10, 64); err == nil {
+		entry.Date = time.Unix(ts, 0)
+	}
+
+	return entry, nil
+}
+
+// countStashFiles counts tracked and untracked files recorded in a stash
+func countStashFiles(ref string) (int, error) {
+	output, err := RunCommand(fmt.Sprintf("git stash show --name-only %s", quoteArg(ref)))
+	if err != nil {
+		return 0, err
+	}
+	count := countLines(output)
+
+	// Untracked files live in the third parent when the stash was
+	// created with --include-untracked
+	if _, err := RunCommand(fmt.Sprintf("git rev-parse --verify --quiet %s", quoteArg(ref+"^3"))); err == nil {
+		untracked, err := RunCommand(fmt.Sprintf("git ls-tree -r --name-only %s", quoteArg(ref+"^3")))
+		if err != nil {
+			return 0, err
+		}
+		count += countLines(untracked)
+	}
+
+	return count, nil
+}
+
+// countLines counts the non-empty lines in output
+func countLines(output string) int {
+	count := 0
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) != "" {
+			count++
+		}
+	}
+	return count
+}
+
+// StashPush stashes changes according to opts
+func StashPush(opts StashOptions) error {
+	command := "git stash push"
+	if opts.IncludeUntracked {
+		command += " --include-untracked"
+	}
+	if opts.KeepIndex {
+		command += " --keep-index"
+	}
+	if opts.Message != "" {
+		command += " -m " + quoteArg(opts.Message)
+	}
+	if len(opts.Paths) > 0 {
+		command += " -- " + quoteArgs(opts.Paths)
+	}
+	_, err := RunCommand(command)
+	return err
+}
+
+// ShowStash returns the patch recorded in the stash at index
+func ShowStash(index int) (string, error) {
+	return RunCommand(fmt.Sprintf("git stash show -p %s", quoteArg(stashRef(index))))
+}
+
+// ApplyStash applies the stash at index and keeps it in the stash list
+func ApplyStash(index int) error {
+	_, err := RunCommand(fmt.Sprintf("git stash apply %s", quoteArg(stashRef(index))))
+	return err
+}
+
+// PopStash applies the stash at index and removes it from the stash list
+func PopStash(index int) error {
+	_, err := RunCommand(fmt.Sprintf("git stash pop %s", quoteArg(stashRef(index))))
+	return err
+}
+
+// DropStash removes the stash at index without applying it
+func DropStash(index int) error {
+	_, err := RunCommand(fmt.Sprintf("git stash drop %s", quoteArg(stashRef(index))))
+	return err
+}
+
+// StashBranch creates and checks out a branch from the commit the stash
+// at index was based on, applies the stash and drops it on success
+func StashBranch(branch string, index int) error {
+	_, err := RunCommand(fmt.Sprintf("git stash branch %s %s", quoteArg(branch), quoteArg(stashRef(index))))
+	return err
+}
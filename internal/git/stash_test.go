@@ -0,0 +1,140 @@
+package git
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParseStashLine(t *testing.T) {
+	tests := []struct {
+		name        string
+		line        string
+		wantIndex   int
+		wantBranch  string
+		wantMessage string
+	}{
+		{
+			name:        "stash with message",
+			line:        "stash@{0}\x1fOn main: work in progress\x1f1700000000",
+			wantIndex:   0,
+			wantBranch:  "main",
+			wantMessage: "work in progress",
+		},
+		{
+			name:        "stash without message",
+			line:        "stash@{3}\x1fWIP on feature/x: abc1234 Add thing\x1f1700000000",
+			wantIndex:   3,
+			wantBranch:  "feature/x",
+			wantMessage: "WIP: abc1234 Add thing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := parseStashLine(tt.line)
+			if err != nil {
+				t.Fatalf("parseStashLine() error = %v", err)
+			}
+			if entry.Index != tt.wantIndex {
+				t.Errorf("Index = %d, want %d", entry.Index, tt.wantIndex)
+			}
+			if entry.Branch != tt.wantBranch {
+				t.Errorf("Branch = %q, want %q", entry.Branch, tt.wantBranch)
+			}
+			if entry.Message != tt.wantMessage {
+				t.Errorf("Message = %q, want %q", entry.Message, tt.wantMessage)
+			}
+			if entry.Date.Unix() != 1700000000 {
+				t.Errorf("Date = %v, want unix 1700000000", entry.Date)
+			}
+		})
+	}
+
+	if _, err := parseStashLine("garbage"); err == nil {
+		t.Error("parseStashLine() should fail on malformed input")
+	}
+}
+
+func TestStashBrowserOperations(t *testing.T) {
+	// Set up test repository
+	_, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	createTestFile(t, "a.txt", "a")
+	createTestFile(t, "b.txt", "b")
+	createTestCommit(t, "Initial commit")
+
+	// Stash only a.txt
+	createTestFile(t, "a.txt", "a modified")
+	createTestFile(t, "b.txt", "b modified")
+	if err := StashPush(StashOptions{Message: "only a", Paths: []string{"a.txt"}}); err != nil {
+		t.Fatalf("StashPush() error = %v", err)
+	}
+	assertFileContent(t, "a.txt", "a")
+	assertFileContent(t, "b.txt", "b modified")
+
+	// Stash the rest including an untracked file
+	createTestFile(t, "new.txt", "untracked")
+	if err := StashPush(StashOptions{Message: "with untracked", IncludeUntracked: true}); err != nil {
+		t.Fatalf("StashPush() error = %v", err)
+	}
+	if _, err := os.Stat("new.txt"); !os.IsNotExist(err) {
+		t.Error("StashPush() with IncludeUntracked should remove untracked files")
+	}
+
+	stashes, err := ListStashes()
+	if err != nil {
+		t.Fatalf("ListStashes() error = %v", err)
+	}
+	if len(stashes) != 2 {
+		t.Fatalf("ListStashes() returned %d entries, want 2", len(stashes))
+	}
+	if stashes[0].Message != "with untracked" || stashes[0].FilesChanged != 2 {
+		t.Errorf("stashes[0] = %+v, want message %q with 2 files", stashes[0], "with untracked")
+	}
+	if stashes[1].Message != "only a" || stashes[1].FilesChanged != 1 {
+		t.Errorf("stashes[1] = %+v, want message %q with 1 file", stashes[1], "only a")
+	}
+	if stashes[1].Branch != "main" {
+		t.Errorf("stashes[1].Branch = %q, want main", stashes[1].Branch)
+	}
+
+	// Show and apply a specific, non-top entry
+	diff, err := ShowStash(1)
+	if err != nil {
+		t.Fatalf("ShowStash() error = %v", err)
+	}
+	if !strings.Contains(diff, "a modified") {
+		t.Errorf("ShowStash() = %v, want diff of a.txt", diff)
+	}
+	if err := ApplyStash(1); err != nil {
+		t.Fatalf("ApplyStash() error = %v", err)
+	}
+	assertFileContent(t, "a.txt", "a modified")
+
+	// Drop the applied entry
+	if err := DropStash(1); err != nil {
+		t.Fatalf("DropStash() error = %v", err)
+	}
+	stashes, _ = ListStashes()
+	if len(stashes) != 1 {
+		t.Fatalf("DropStash() left %d entries, want 1", len(stashes))
+	}
+
+	// Create a branch from the remaining stash
+	if _, err := RunCommand("git checkout -- a.txt"); err != nil {
+		t.Fatalf("Failed to reset a.txt: %v", err)
+	}
+	if err := StashBranch("from-stash", 0); err != nil {
+		t.Fatalf("StashBranch() error = %v", err)
+	}
+	assertGitBranch(t, "from-stash")
+	assertFileContent(t, "new.txt", "untracked")
+	assertFileContent(t, "b.txt", "b modified")
+
+	stashes, _ = ListStashes()
+	if len(stashes) != 0 {
+		t.Errorf("StashBranch() should drop the stash, %d entries left", len(stashes))
+	}
+}
@@ -0,0 +1,210 @@
+/*
+ * GitHubber - Git Tag Operations
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Typed tag listing, tag creation and remote tag synchronisation
+ */
+
+package git
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tag describes a lightweight or annotated tag
+type Tag struct {
+	Name      string
+	Commit    string
+	Tagger    string
+	Date      time.Time
+	Message   string
+	Annotated bool
+	Signed    bool
+}
+
+// TagOptions controls how CreateTagWithOptions creates a tag
+type TagOptions struct {
+	Commit    string // Commit to tag, defaults to HEAD
+	Message   string
+	Annotated bool
+}
+
+// TagComparison lists tag names that exist on only one side
+type TagComparison struct {
+	LocalOnly  []string
+	RemoteOnly []string
+}
+
+// tagFormat is the for-each-ref format parsed by parseTagRecord. Fields are
+// separated by US and records by RS since messages may span several lines.
+const tagFormat = "%(refname:short)%1f%(objecttype)%1f%(objectname)%1f%(*objectname)%1f" +
+	"%(taggername) %(taggeremail)%1f%(creatordate:unix)%1f%(contents:subject)%1f" +
+	"%(contents:body)%1f%(contents:signature)%1f%(*objecttype)%1e"
+
+// ListTags returns all local tags, newest first
+func ListTags() ([]Tag, error) {
+	output, err := RunCommand(fmt.Sprintf("git for-each-ref refs/tags --sort=-creatordate --format=%s", quoteArg(tagFormat)))
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []Tag
+	for _, record := range strings.Split(output, "\x1e") {
+		record = strings.TrimPrefix(record, "\n")
+		if strings.TrimSpace(record) == "" {
+			continue
+		}
+		tag, err := parseTagRecord(record)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// parseTagRecord parses a single record produced with tagFormat
+func parseTagRecord(record string) (Tag, error) {
+	fields := strings.Split(record, "\x1f")
+	if len(fields) != 10 {
+		return Tag{}, fmt.Errorf("unexpected tag record: %q", record)
+	}
+
+	tag := Tag{
+		Name:      fields[0],
+		Commit:    fields[2],
+		Annotated: fields[1] == "tag",
+		Signed:    strings.TrimSpace(fields[8]) != "",
+	}
+
+	if tag.Annotated {
+		// Annotated tags point at a tag object; the peeled value is the commit
+		tag.Commit = fields[3]
+		tag.Tagger = strings.TrimSpace(fields[4])
+		tag.Message = strings.TrimSpace(fields[6] + "\n\n" + fields[7])
+	}
+	if fields[9] == "tag" {
+		// for-each-ref peels a single level, so a tag of a tag needs
+		// resolving all the way down to its commit
+		commit, err := RunCommand(fmt.Sprintf("git rev-parse --verify --quiet %s", quoteArg("refs/tags/"+tag.Name+"^{commit}")))
+		if err != nil {
+			return Tag{}, fmt.Errorf("tag %s does not point at a commit", tag.Name)
+		}
+		tag.Commit = commit
+	}
+
+	if ts, err := strconv.ParseInt(fields[5], 10, 64); err == nil {
+		tag.Date = time.Unix(ts, 0)
+	}
+
+	return tag, nil
+}
+
+// CreateTagWithOptions creates a tag according to opts. Annotated tags are
+// signed when signing has been enabled with ConfigureSigning.
+func CreateTagWithOptions(name string, opts TagOptions) error {
+	command := gitCommand() + " tag"
+	if opts.Annotated {
+		flag := " -a "
+		if signing.Enabled {
+			flag = " -s "
+		}
+		command += flag + quoteArg(name) + " -m " + quoteArg(opts.Message)
+	} else {
+		command += " " + quoteArg(name)
+	}
+	if opts.Commit != "" {
+		command += " " + quoteArg(opts.Commit)
+	}
+	_, err := RunCommand(command)
+	return err
+}
+
+// PushTag pushes a single tag to remote
+func PushTag(remote, name string) error {
+	_, err := RunCommand(fmt.Sprintf("git push %s %s", quoteArg(remote), quoteArg("refs/tags/"+name)))
+	return err
+}
+
+// PushAllTags pushes every local tag to remote
+func PushAllTags(remote string) error {
+	_, err := RunCommand(fmt.Sprintf("git push %s --tags", quoteArg(remote)))
+	return err
+}
+
+// DeleteRemoteTag deletes a tag from remote
+func DeleteRemoteTag(remote, name string) error {
+	_, err := RunCommand(fmt.Sprintf("git push %s --delete %s", quoteArg(remote), quoteArg("refs/tags/"+name)))
+	return err
+}
+
+// ListRemoteTags returns the names of all tags on remote
+func ListRemoteTags(remote string) ([]string, error) {
+	output, err := RunCommand(fmt.Sprintf("git ls-remote --tags --refs %s", quoteArg(remote)))
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		names = append(names, strings.TrimPrefix(fields[1], "refs/tags/"))
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// CompareTags reports which tags exist locally but not on remote and vice versa
+func CompareTags(remote string) (*TagComparison, error) {
+	local, err := ListTags()
+	if err != nil {
+		return nil, err
+	}
+	remoteNames, err := ListRemoteTags(remote)
+	if err != nil {
+		return nil, err
+	}
+
+	localNames := make([]string, 0, len(local))
+	for _, tag := range local {
+		localNames = append(localNames, tag.Name)
+	}
+
+	return compareTagNames(localNames, remoteNames), nil
+}
+
+// compareTagNames computes the sorted differences between two tag name sets
+func compareTagNames(local, remote []string) *TagComparison {
+	onRemote := make(map[string]bool, len(remote))
+	for _, name := range remote {
+		onRemote[name] = true
+	}
+	onLocal := make(map[string]bool, len(local))
+	for _, name := range local {
+		onLocal[name] = true
+	}
+
+	comparison := &TagComparison{}
+	for _, name := range local {
+		if !onRemote[name] {
+			comparison.LocalOnly = append(comparison.LocalOnly, name)
+		}
+	}
+	for _, name := range remote {
+		if !onLocal[name] {
+			comparison.RemoteOnly = append(comparison.RemoteOnly, name)
+		}
+	}
+	sort.Strings(comparison.LocalOnly)
+	sort.Strings(comparison.RemoteOnly)
+
+	return comparison
+}
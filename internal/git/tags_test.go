@@ -0,0 +1,136 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestListTagsTyped(t *testing.T) {
+	// Set up test repository
+	_, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	createTestFile(t, "test.txt", "one")
+	createTestCommit(t, "First commit")
+	first, err := RunCommand("git rev-parse HEAD")
+	if err != nil {
+		t.Fatalf("Failed to resolve HEAD: %v", err)
+	}
+
+	createTestFile(t, "test.txt", "two")
+	createTestCommit(t, "Second commit")
+
+	// Annotated tag at an older commit
+	if err := CreateTagWithOptions("v0.1.0", TagOptions{Commit: first, Message: "First release\n\nWith notes", Annotated: true}); err != nil {
+		t.Fatalf("CreateTagWithOptions() error = %v", err)
+	}
+	// Lightweight tag at HEAD
+	if err := CreateTagWithOptions("latest", TagOptions{}); err != nil {
+		t.Fatalf("CreateTagWithOptions() error = %v", err)
+	}
+	// Annotated tag of the annotated tag
+	if err := CreateTagWithOptions("v0.1.0-final", TagOptions{Commit: "v0.1.0", Message: "Tag of a tag", Annotated: true}); err != nil {
+		t.Fatalf("CreateTagWithOptions() error = %v", err)
+	}
+
+	tags, err := ListTags()
+	if err != nil {
+		t.Fatalf("ListTags() error = %v", err)
+	}
+	if len(tags) != 3 {
+		t.Fatalf("ListTags() returned %d tags, want 3", len(tags))
+	}
+
+	byName := make(map[string]Tag)
+	for _, tag := range tags {
+		byName[tag.Name] = tag
+	}
+
+	annotated := byName["v0.1.0"]
+	if !annotated.Annotated {
+		t.Error("v0.1.0 should be annotated")
+	}
+	if annotated.Commit != first {
+		t.Errorf("v0.1.0 Commit = %s, want %s", annotated.Commit, first)
+	}
+	if annotated.Message != "First release\n\nWith notes" {
+		t.Errorf("v0.1.0 Message = %q", annotated.Message)
+	}
+	if annotated.Tagger != "Test User <test@example.com>" {
+		t.Errorf("v0.1.0 Tagger = %q", annotated.Tagger)
+	}
+	if annotated.Signed {
+		t.Error("v0.1.0 should not be signed")
+	}
+
+	if nested := byName["v0.1.0-final"]; nested.Commit != first {
+		t.Errorf("v0.1.0-final Commit = %s, want %s", nested.Commit, first)
+	}
+
+	lightweight := byName["latest"]
+	if lightweight.Annotated {
+		t.Error("latest should be lightweight")
+	}
+	if head, _ := RunCommand("git rev-parse HEAD"); lightweight.Commit != head {
+		t.Errorf("latest Commit = %s, want %s", lightweight.Commit, head)
+	}
+	if lightweight.Date.IsZero() {
+		t.Error("latest should carry the commit date")
+	}
+}
+
+func TestRemoteTagOperations(t *testing.T) {
+	// Set up test repository with a local bare remote
+	_, cleanup := setupTestRepo(t)
+	defer cleanup()
+	_, cleanupRemote := setupTestRemote(t, "origin")
+	defer cleanupRemote()
+
+	createTestFile(t, "test.txt", "test content")
+	createTestCommit(t, "Initial commit")
+
+	if err := CreateTag("v1.0.0", "Version 1.0.0"); err != nil {
+		t.Fatalf("CreateTag() error = %v", err)
+	}
+	if err := CreateTag("v1.1.0", "Version 1.1.0"); err != nil {
+		t.Fatalf("CreateTag() error = %v", err)
+	}
+
+	if err := PushTag("origin", "v1.0.0"); err != nil {
+		t.Fatalf("PushTag() error = %v", err)
+	}
+
+	comparison, err := CompareTags("origin")
+	if err != nil {
+		t.Fatalf("CompareTags() error = %v", err)
+	}
+	if !reflect.DeepEqual(comparison.LocalOnly, []string{"v1.1.0"}) || len(comparison.RemoteOnly) != 0 {
+		t.Errorf("CompareTags() = %+v, want only v1.1.0 local", comparison)
+	}
+
+	if err := PushAllTags("origin"); err != nil {
+		t.Fatalf("PushAllTags() error = %v", err)
+	}
+	if err := DeleteTag("v1.0.0"); err != nil {
+		t.Fatalf("DeleteTag() error = %v", err)
+	}
+
+	comparison, err = CompareTags("origin")
+	if err != nil {
+		t.Fatalf("CompareTags() error = %v", err)
+	}
+	if !reflect.DeepEqual(comparison.RemoteOnly, []string{"v1.0.0"}) || len(comparison.LocalOnly) != 0 {
+		t.Errorf("CompareTags() = %+v, want only v1.0.0 remote", comparison)
+	}
+
+	if err := DeleteRemoteTag("origin", "v1.0.0"); err != nil {
+		t.Fatalf("DeleteRemoteTag() error = %v", err)
+	}
+	remoteTags, err := ListRemoteTags("origin")
+	if err != nil {
+		t.Fatalf("ListRemoteTags() error = %v", err)
+	}
+	if !reflect.DeepEqual(remoteTags, []string{"v1.1.0"}) {
+		t.Errorf("ListRemoteTags() = %v, want [v1.1.0]", remoteTags)
+	}
+}
@@ -144,3 +144,33 @@ func assertGitBranch(t *testing.T, expected string) {
 		t.Errorf("Expected to be on branch %s, but was on %s", expected, branch)
 	}
 }
+
+// setupTestRemote creates a bare repository and registers it as the named
+// remote of the current test repository. The bare repository is removed by
+// the returned cleanup function.
+func setupTestRemote(t *testing.T, name string) (string, func()) {
+	t.Helper()
+
+	remoteDir, err := os.MkdirTemp("", "git-tool-remote-*")
+	if err != nil {
+		t.Fatalf("Failed to create remote directory: %v", err)
+	}
+
+	cmd := exec.Command("git", "init", "--bare", remoteDir)
+	if err := cmd.Run(); err != nil {
+		os.RemoveAll(remoteDir)
+		t.Fatalf("Failed to initialize bare remote: %v", err)
+	}
+
+	cmd = exec.Command("git", "remote", "add", name, remoteDir)
+	if err := cmd.Run(); err != nil {
+		os.RemoveAll(remoteDir)
+		t.Fatalf("Failed to add remote %s: %v", name, err)
+	}
+
+	cleanup := func() {
+		os.RemoveAll(remoteDir)
+	}
+
+	return remoteDir, cleanup
+}
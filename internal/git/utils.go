@@ -8,11 +8,13 @@ package git
 
 import (
     "fmt"
+    "os"
     "os/exec"
     "strings"
 )
 
 type RepositoryInfo struct {
+    Remote        string
     URL           string
     CurrentBranch string
 }
@@ -24,10 +26,16 @@ func RunCommand(command string) (string, error) {
     return strings.TrimSpace(string(output)), err
 }
 
-// GetRepositoryInfo retrieves current repository information
+// GetRepositoryInfo retrieves current repository information. The URL is
+// taken from the remote the current branch tracks, falling back to origin
+// or the first configured remote.
 func GetRepositoryInfo() (*RepositoryInfo, error) {
     // Get remote URL
-    url, err := RunCommand("git remote get-url origin")
+    remote, err := CurrentRemote()
+    if err != nil {
+        return nil, fmt.Errorf("failed to get repository URL: %w", err)
+    }
+    url, err := RemoteURL(remote)
     if err != nil {
         return nil, fmt.Errorf("failed to get repository URL: %w", err)
     }
@@ -39,11 +47,48 @@ func GetRepositoryInfo() (*RepositoryInfo, error) {
     }
 
     return &RepositoryInfo{
+        Remote:        remote,
         URL:           url,
         CurrentBranch: branch,
     }, nil
 }
 
+// quoteArg quotes s so it is passed to the shell as a single literal argument
+func quoteArg(s string) string {
+    return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// quoteArgs quotes every element of args and joins them with spaces
+func quoteArgs(args []string) string {
+    quoted := make([]string, len(args))
+    for i, arg := range args {
+        quoted[i] = quoteArg(arg)
+    }
+    return strings.Join(quoted, " ")
+}
+
+// writeMessageFile writes a commit or tag message to a temporary file and
+// returns its path along with a function that removes it
+func writeMessageFile(message string) (string, func(), error) {
+    file, err := os.CreateTemp("", "githubber-msg-*")
+    if err != nil {
+        return "", nil, fmt.Errorf("failed to create message file: %w", err)
+    }
+    cleanup := func() { os.Remove(file.Name()) }
+
+    if _, err := file.WriteString(message); err != nil {
+        file.Close()
+        cleanup()
+        return "", nil, fmt.Errorf("failed to write message file: %w", err)
+    }
+    if err := file.Close(); err != nil {
+        cleanup()
+        return "", nil, fmt.Errorf("failed to write message file: %w", err)
+    }
+
+    return file.Name(), cleanup, nil
+}
+
 // IsWorkingDirectoryClean checks if there are any uncommitted changes
 func IsWorkingDirectoryClean() (bool, error) {
     output, err := RunCommand("git status --porcelain")
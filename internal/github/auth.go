@@ -0,0 +1,178 @@
+/*
+ * GitHubber - GitHub Login
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: OAuth device authorization flow and token scope checks
+ */
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// DefaultScopes are the OAuth scopes GitHubber asks for when logging in
+var DefaultScopes = []string{"repo", "read:org", "read:user"}
+
+// scopeImplies lists the scopes granted implicitly by a broader scope
+var scopeImplies = map[string][]string{
+	"repo":             {"repo:status", "repo_deployment", "public_repo", "repo:invite", "security_events"},
+	"admin:org":        {"write:org"},
+	"write:org":        {"read:org"},
+	"admin:public_key": {"write:public_key"},
+	"write:public_key": {"read:public_key"},
+	"admin:repo_hook":  {"write:repo_hook"},
+	"write:repo_hook":  {"read:repo_hook"},
+	"user":             {"read:user", "user:email", "user:follow"},
+	"write:packages":   {"read:packages"},
+	"project":          {"read:project"},
+}
+
+// DeviceFlow logs in to a GitHub host with the OAuth device authorization
+// grant: the user enters a one-time code in the browser while GitHubber
+// polls for the resulting token
+type DeviceFlow struct {
+	config oauth2.Config
+}
+
+// DeviceCode is the one-time code the user enters at VerificationURI
+type DeviceCode struct {
+	UserCode        string
+	VerificationURI string
+	Expiry          time.Time
+
+	response *oauth2.DeviceAuthResponse
+}
+
+// LoginToken is a token obtained through the device flow
+type LoginToken struct {
+	Token  string
+	Scopes []string // Scopes the user actually granted
+}
+
+// NewDeviceFlow returns the device flow for host using the OAuth app
+// identified by clientID
+func NewDeviceFlow(host, clientID string, scopes []string) *DeviceFlow {
+	return newDeviceFlow("https://"+host, clientID, scopes)
+}
+
+// newDeviceFlow returns the device flow against the web endpoints at baseURL
+func newDeviceFlow(baseURL, clientID string, scopes []string) *DeviceFlow {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return &DeviceFlow{config: oauth2.Config{
+		ClientID: clientID,
+		Scopes:   scopes,
+		Endpoint: oauth2.Endpoint{
+			DeviceAuthURL: baseURL + "/login/device/code",
+			TokenURL:      baseURL + "/login/oauth/access_token",
+			AuthStyle:     oauth2.AuthStyleInParams,
+		},
+	}}
+}
+
+// Start requests a device and user code
+func (f *DeviceFlow) Start(ctx context.Context) (*DeviceCode, error) {
+	response, err := f.config.DeviceAuth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start device login: %w", deviceFlowError(err))
+	}
+	return &DeviceCode{
+		UserCode:        response.UserCode,
+		VerificationURI: response.VerificationURI,
+		Expiry:          response.Expiry,
+		response:        response,
+	}, nil
+}
+
+// Wait polls until the user has authorized the code, denied it, or the
+// code expired
+func (f *DeviceFlow) Wait(ctx context.Context, code *DeviceCode) (*LoginToken, error) {
+	token, err := f.config.DeviceAccessToken(ctx, code.response)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) && !code.Expiry.IsZero() && time.Now().After(code.Expiry) {
+			return nil, fmt.Errorf("the code expired before it was entered, please log in again")
+		}
+		return nil, deviceFlowError(err)
+	}
+
+	login := &LoginToken{Token: token.AccessToken}
+	if scope, ok := token.Extra("scope").(string); ok {
+		login.Scopes = parseScopes(scope)
+	}
+	return login, nil
+}
+
+// deviceFlowError explains the OAuth error codes GitHub returns
+func deviceFlowError(err error) error {
+	var retrieveErr *oauth2.RetrieveError
+	if !errors.As(err, &retrieveErr) {
+		return err
+	}
+	switch retrieveErr.ErrorCode {
+	case "access_denied":
+		return fmt.Errorf("authorization was denied")
+	case "expired_token":
+		return fmt.Errorf("the code expired before it was entered, please log in again")
+	case "device_flow_disabled":
+		return fmt.Errorf("device flow is not enabled for the OAuth app")
+	case "incorrect_client_credentials":
+		return fmt.Errorf("the OAuth client ID is not valid")
+	case "":
+		return err
+	}
+	if retrieveErr.ErrorDescription == "" {
+		return errors.New(retrieveErr.ErrorCode)
+	}
+	return fmt.Errorf("%s: %s", retrieveErr.ErrorCode, retrieveErr.ErrorDescription)
+}
+
+// parseScopes splits a comma or space separated scope list
+func parseScopes(scopes string) []string {
+	return strings.FieldsFunc(scopes, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
+
+// MissingScopes returns the scopes in required that granted neither
+// contains nor implies
+func MissingScopes(granted, required []string) []string {
+	have := make(map[string]bool)
+	pending := append([]string(nil), granted...)
+	for len(pending) > 0 {
+		scope := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if have[scope] {
+			continue
+		}
+		have[scope] = true
+		pending = append(pending, scopeImplies[scope]...)
+	}
+
+	var missing []string
+	for _, scope := range required {
+		if !have[scope] {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+// TokenScopes returns the OAuth scopes of the client's token. Tokens
+// without classic scopes (fine-grained tokens, GitHub App tokens) report
+// ok == false.
+func (c *Client) TokenScopes() (scopes []string, ok bool, err error) {
+	_, resp, err := c.client.Users.Get(c.ctx, "")
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get token scopes: %w", classifyError(err))
+	}
+	header, ok := resp.Header["X-Oauth-Scopes"]
+	if !ok || len(header) == 0 {
+		return nil, false, nil
+	}
+	return parseScopes(header[0]), true, nil
+}
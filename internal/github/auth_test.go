@@ -0,0 +1,127 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeAuthServer implements GitHub's device flow endpoints. The token
+// endpoint answers with each of responses in turn, repeating the last.
+func fakeAuthServer(t *testing.T, responses ...map[string]string) *httptest.Server {
+	t.Helper()
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm() error = %v", err)
+			return
+		}
+		if got := r.PostForm.Get("client_id"); got != "client-id" {
+			t.Errorf("client_id = %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/login/device/code":
+			if got := r.PostForm.Get("scope"); got != "repo read:org" {
+				t.Errorf("scope = %q", got)
+			}
+			json.NewEncoder(w).Encode(map[string]any{
+				"device_code":      "device-code",
+				"user_code":        "ABCD-1234",
+				"verification_uri": "https://github.com/login/device",
+				"expires_in":       900,
+				"interval":         1,
+			})
+		case "/login/oauth/access_token":
+			if got := r.PostForm.Get("device_code"); got != "device-code" {
+				t.Errorf("device_code = %q", got)
+			}
+			response := responses[min(polls, len(responses)-1)]
+			polls++
+			json.NewEncoder(w).Encode(response)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDeviceFlowLogin(t *testing.T) {
+	server := fakeAuthServer(t,
+		map[string]string{"error": "authorization_pending"},
+		map[string]string{"access_token": "gho_token", "token_type": "bearer", "scope": "repo,read:org"},
+	)
+	flow := newDeviceFlow(server.URL, "client-id", []string{"repo", "read:org"})
+
+	code, err := flow.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if code.UserCode != "ABCD-1234" || code.VerificationURI != "https://github.com/login/device" || code.Expiry.IsZero() {
+		t.Errorf("Start() = %+v", code)
+	}
+
+	login, err := flow.Wait(context.Background(), code)
+	if err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if login.Token != "gho_token" || !reflect.DeepEqual(login.Scopes, []string{"repo", "read:org"}) {
+		t.Errorf("Wait() = %+v", login)
+	}
+}
+
+func TestDeviceFlowDenied(t *testing.T) {
+	server := fakeAuthServer(t, map[string]string{"error": "access_denied"})
+	flow := newDeviceFlow(server.URL, "client-id", []string{"repo", "read:org"})
+
+	code, err := flow.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if _, err := flow.Wait(context.Background(), code); err == nil || !strings.Contains(err.Error(), "denied") {
+		t.Errorf("Wait() error = %v, want authorization denied", err)
+	}
+}
+
+func TestMissingScopes(t *testing.T) {
+	tests := []struct {
+		granted []string
+		want    []string
+	}{
+		{[]string{"repo", "read:org", "read:user"}, nil},
+		{[]string{"repo", "admin:org", "user"}, nil},
+		{[]string{"public_repo", "read:org"}, []string{"repo", "read:user"}},
+		{nil, DefaultScopes},
+	}
+	for _, tt := range tests {
+		if got := MissingScopes(tt.granted, DefaultScopes); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("MissingScopes(%v) = %v, want %v", tt.granted, got, tt.want)
+		}
+	}
+}
+
+func TestTokenScopes(t *testing.T) {
+	header := "repo, read:org"
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if header != "" {
+			w.Header().Set("X-OAuth-Scopes", header)
+		}
+		w.Write([]byte(`{"login": "octocat"}`))
+	})
+
+	scopes, ok, err := client.TokenScopes()
+	if err != nil || !ok || !reflect.DeepEqual(scopes, []string{"repo", "read:org"}) {
+		t.Errorf("TokenScopes() = %v, %t, %v", scopes, ok, err)
+	}
+
+	header = ""
+	if _, ok, err := client.TokenScopes(); ok || err != nil {
+		t.Errorf("TokenScopes() without header = %t, %v; want not ok", ok, err)
+	}
+}
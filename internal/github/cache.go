@@ -0,0 +1,245 @@
+/*
+ * GitHubber - GitHub API Cache
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: On-disk HTTP cache with conditional requests and offline fallback
+ */
+
+package github
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CacheHeader is set on responses served from the cache. Its value is
+// "hit" for revalidated responses and "stale" for responses served without
+// reaching GitHub.
+const CacheHeader = "X-Githubber-Cache"
+
+// ErrOffline is returned for requests that cannot be answered from the
+// cache while GitHub is unreachable or offline mode is enabled
+var ErrOffline = errors.New("offline and no cached response available")
+
+// cacheEntry is a cached response as stored on disk
+type cacheEntry struct {
+	URL        string      `json:"url"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+	StoredAt   time.Time   `json:"stored_at"`
+}
+
+// cacheTransport caches GET responses carrying an ETag or Last-Modified
+// header and revalidates them with conditional requests. GitHub does not
+// count 304 Not Modified responses against the rate limit. When GitHub
+// cannot be reached, or offline is set, cached responses are served as is.
+type cacheTransport struct {
+	base    http.RoundTripper
+	dir     string
+	offline bool
+}
+
+func newCacheTransport(base http.RoundTripper, dir string, offline bool) *cacheTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &cacheTransport{base: base, dir: dir, offline: offline}
+}
+
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		if t.offline {
+			return nil, fmt.Errorf("%w: %s %s", ErrOffline, req.Method, req.URL)
+		}
+		return t.base.RoundTrip(req)
+	}
+
+	key := cacheKey(req)
+	entry, _ := t.load(key)
+
+	if t.offline {
+		if entry == nil {
+			return nil, fmt.Errorf("%w: %s", ErrOffline, req.URL)
+		}
+		return entry.response(req, "stale"), nil
+	}
+
+	outgoing := req
+	if entry != nil {
+		outgoing = req.Clone(req.Context())
+		if etag := entry.Header.Get("ETag"); etag != "" {
+			outgoing.Header.Set("If-None-Match", etag)
+		}
+		if modified := entry.Header.Get("Last-Modified"); modified != "" {
+			outgoing.Header.Set("If-Modified-Since", modified)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(outgoing)
+	if err != nil {
+		if entry != nil && !errors.Is(err, context.Canceled) {
+			return entry.response(req, "stale"), nil
+		}
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		resp.Body.Close()
+		// Keep the rate limit headers current for the go-github client
+		for name, values := range resp.Header {
+			if strings.HasPrefix(name, "X-Ratelimit-") {
+				entry.Header[name] = values
+			}
+		}
+		return entry.response(req, "hit"), nil
+	}
+
+	if resp.StatusCode != http.StatusOK || (resp.Header.Get("ETag") == "" && resp.Header.Get("Last-Modified") == "") {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	// A failed write only costs us the next conditional request
+	_ = t.store(key, &cacheEntry{
+		URL:        req.URL.String(),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Body:       body,
+		StoredAt:   time.Now(),
+	})
+	return resp, nil
+}
+
+// response builds an HTTP response for req from the cached entry
+func (e *cacheEntry) response(req *http.Request, source string) *http.Response {
+	header := e.Header.Clone()
+	header.Set(CacheHeader, source)
+	if source == "stale" {
+		// Rate limit headers of stale responses are outdated, tell
+		// go-github not to track them
+		header.Set("X-From-Cache", "1")
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode)),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// cacheKey identifies a response by URL and the request headers GitHub
+// varies its responses on, so different tokens never share entries
+func cacheKey(req *http.Request) string {
+	hash := sha256.New()
+	for _, part := range []string{req.URL.String(), req.Header.Get("Accept"), req.Header.Get("Authorization")} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func (t *cacheTransport) load(key string) (*cacheEntry, error) {
+	data, err := os.ReadFile(filepath.Join(t.dir, key+".json"))
+	if err != nil {
+		return nil, err
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+	if entry.Header == nil {
+		entry.Header = http.Header{}
+	}
+	return &entry, nil
+}
+
+func (t *cacheTransport) store(key string, entry *cacheEntry) error {
+	if err := os.MkdirAll(t.dir, 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(t.dir, key+".json"), data)
+}
+
+// writeFileAtomic writes data to a temporary file first and renames it into
+// place, so readers never see a partially written file
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// CacheStats describes the on-disk response cache
+type CacheStats struct {
+	Entries int
+	Bytes   int64
+}
+
+// GetCacheStats returns the number and total size of cached responses in dir
+func GetCacheStats(dir string) (*CacheStats, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache: %w", err)
+	}
+
+	stats := &CacheStats{}
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		stats.Entries++
+		stats.Bytes += info.Size()
+	}
+	return stats, nil
+}
+
+// ClearCache removes every cached response in dir
+func ClearCache(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return fmt.Errorf("failed to read cache: %w", err)
+	}
+	for _, file := range files {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove cached response: %w", err)
+		}
+	}
+	return nil
+}
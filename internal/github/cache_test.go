@@ -0,0 +1,140 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// cacheServer serves a body with an ETag, answering matching conditional
+// requests with 304 Not Modified
+func cacheServer(t *testing.T, requests *[]*http.Request) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r)
+		w.Header().Set("X-RateLimit-Remaining", fmt.Sprint(100-len(*requests)))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, "payload")
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func get(t *testing.T, transport http.RoundTripper, url string) (*http.Response, string, error) {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	req.Header.Set("Authorization", "Bearer token")
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp, string(body), nil
+}
+
+func TestCacheConditionalRequest(t *testing.T) {
+	var requests []*http.Request
+	server := cacheServer(t, &requests)
+	transport := newCacheTransport(http.DefaultTransport, t.TempDir(), false)
+
+	resp, body, err := get(t, transport, server.URL+"/repos/o/r")
+	if err != nil || body != "payload" || resp.Header.Get(CacheHeader) != "" {
+		t.Fatalf("first request = %q, %v (cache header %q)", body, err, resp.Header.Get(CacheHeader))
+	}
+
+	resp, body, err = get(t, transport, server.URL+"/repos/o/r")
+	if err != nil {
+		t.Fatalf("second request error = %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || body != "payload" || resp.Header.Get(CacheHeader) != "hit" {
+		t.Errorf("second request = %d %q (cache header %q), want cached payload", resp.StatusCode, body, resp.Header.Get(CacheHeader))
+	}
+	if got := requests[1].Header.Get("If-None-Match"); got != `"v1"` {
+		t.Errorf("If-None-Match = %q, want \"v1\"", got)
+	}
+	if got := resp.Header.Get("X-RateLimit-Remaining"); got != "98" {
+		t.Errorf("X-RateLimit-Remaining = %q, want the revalidated value 98", got)
+	}
+}
+
+func TestCacheSeparatesTokens(t *testing.T) {
+	var requests []*http.Request
+	server := cacheServer(t, &requests)
+	transport := newCacheTransport(http.DefaultTransport, t.TempDir(), false)
+
+	get(t, transport, server.URL+"/user")
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/user", nil)
+	req.Header.Set("Authorization", "Bearer other")
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	resp.Body.Close()
+
+	if requests[1].Header.Get("If-None-Match") != "" {
+		t.Error("request with another token reused a cached response")
+	}
+}
+
+func TestCacheStaleWhenUnreachable(t *testing.T) {
+	var requests []*http.Request
+	server := cacheServer(t, &requests)
+	dir := t.TempDir()
+	url := server.URL + "/repos/o/r"
+
+	if _, _, err := get(t, newCacheTransport(http.DefaultTransport, dir, false), url); err != nil {
+		t.Fatalf("priming request error = %v", err)
+	}
+	server.Close()
+
+	resp, body, err := get(t, newCacheTransport(http.DefaultTransport, dir, false), url)
+	if err != nil {
+		t.Fatalf("request while unreachable error = %v", err)
+	}
+	if body != "payload" || resp.Header.Get(CacheHeader) != "stale" || resp.Header.Get("X-From-Cache") == "" {
+		t.Errorf("request while unreachable = %q (cache header %q)", body, resp.Header.Get(CacheHeader))
+	}
+
+	if _, _, err := get(t, newCacheTransport(http.DefaultTransport, dir, false), server.URL+"/uncached"); err == nil {
+		t.Error("uncached request while unreachable succeeded")
+	}
+}
+
+func TestCacheOffline(t *testing.T) {
+	var requests []*http.Request
+	server := cacheServer(t, &requests)
+	dir := t.TempDir()
+
+	get(t, newCacheTransport(http.DefaultTransport, dir, false), server.URL+"/repos/o/r")
+	offline := newCacheTransport(http.DefaultTransport, dir, true)
+
+	_, body, err := get(t, offline, server.URL+"/repos/o/r")
+	if err != nil || body != "payload" {
+		t.Errorf("offline cached request = %q, %v", body, err)
+	}
+	if _, _, err := get(t, offline, server.URL+"/repos/o/other"); !errors.Is(err, ErrOffline) {
+		t.Errorf("offline uncached request error = %v, want ErrOffline", err)
+	}
+	if len(requests) != 1 {
+		t.Errorf("offline mode made %d requests, want none", len(requests)-1)
+	}
+
+	stats, err := GetCacheStats(dir)
+	if err != nil || stats.Entries != 1 {
+		t.Fatalf("GetCacheStats() = %+v, %v", stats, err)
+	}
+	if err := ClearCache(dir); err != nil {
+		t.Fatalf("ClearCache() error = %v", err)
+	}
+	if stats, _ := GetCacheStats(dir); stats.Entries != 0 {
+		t.Errorf("entries after ClearCache() = %d", stats.Entries)
+	}
+}
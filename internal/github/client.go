@@ -9,10 +9,12 @@ package github
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
-	"strings"
+	"time"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/ritankarsaha/git-tool/internal/config"
 	"golang.org/x/oauth2"
 )
 
@@ -22,14 +24,20 @@ type Client struct {
 }
 
 type Repository struct {
-	Name        string
-	Owner       string
-	Description string
-	URL         string
-	Private     bool
-	Language    string
-	Stars       int
-	Forks       int
+	Name          string
+	Owner         string
+	Description   string
+	URL           string
+	Private       bool
+	Language      string
+	Stars         int
+	Forks         int
+	DefaultBranch string
+
+	// MergeMethods lists the merge methods allowed for pull requests, or
+	// all of them when GitHub does not report the repository's settings
+	MergeMethods        []string
+	DeleteBranchOnMerge bool // GitHub deletes head branches after merging
 }
 
 type PullRequest struct {
@@ -38,6 +46,33 @@ type PullRequest struct {
 	State  string
 	Author string
 	URL    string
+
+	// The fields below are only filled in by GetPullRequest and
+	// GetPullRequestDetail; listings leave the counts and mergeability empty
+	Body               string
+	Draft              bool
+	Merged             bool
+	Head               string // Branch the changes come from
+	HeadRepo           string // "owner/name" of the head repository, differs for forks
+	HeadSHA            string
+	Base               string
+	Mergeable          *bool  // nil while GitHub is still computing it
+	MergeableState     string // e.g. "clean", "blocked", "behind", "dirty"
+	Labels             []string
+	RequestedReviewers []string // Users, and teams as "org/team"
+	Commits            int
+	Additions          int
+	Deletions          int
+	ChangedFiles       int
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+
+	// Where to fetch the head branch from. MaintainerCanModify is set when
+	// the author of a pull request from a fork lets maintainers of the base
+	// repository push to its branch.
+	HeadCloneURL        string // HTTPS
+	HeadSSHURL          string
+	MaintainerCanModify bool
 }
 
 type Issue struct {
@@ -48,27 +83,61 @@ type Issue struct {
 	URL    string
 }
 
-// NewClient creates a new GitHub API client
+// RateLimit is the quota of one GitHub API rate limit bucket
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// RateLimits holds the quotas of the rate limit buckets GitHubber uses
+type RateLimits struct {
+	Core    RateLimit
+	Search  RateLimit
+	GraphQL RateLimit
+}
+
+// NewClient creates a new GitHub API client for the configured default host
 func NewClient() (*Client, error) {
-	ctx := context.Background()
-	
-	// Try to get token from environment variable
-	token := os.Getenv("GITHUB_TOKEN")
-	if token == "" {
-		// Try to get token from GitHub CLI if available
-		token = getGitHubCLIToken()
-		if token == "" {
-			return nil, fmt.Errorf("GitHub token not found. Please set GITHUB_TOKEN environment variable or use 'gh auth login'")
-		}
+	return NewClientForHost("")
+}
+
+// NewClientForHost creates a new GitHub API client for repositories on
+// host, using the API endpoints configured for it and the token found by
+// ResolveCredential
+func NewClientForHost(host string) (*Client, error) {
+	cfg := loadConfig()
+	if host == "" {
+		host = cfg.DefaultHost()
 	}
 
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token},
-	)
-	tc := oauth2.NewClient(ctx, ts)
+	credential, err := resolveCredential(cfg, host)
+	if err != nil && host == "github.com" {
+		return nil, fmt.Errorf("GitHub token not found. Please set GITHUB_TOKEN environment variable or run 'githubber login'")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GitHub token for %s not found. Please set GH_ENTERPRISE_TOKEN or run 'githubber login --host %s'", host, host)
+	}
+
+	return newClientForHost(context.Background(), cfg, host, credential.Token)
+}
+
+// NewClientForHostWithToken creates a new GitHub API client for
+// repositories on host using the provided token
+func NewClientForHostWithToken(host, token string) (*Client, error) {
+	cfg := loadConfig()
+	if host == "" {
+		host = cfg.DefaultHost()
+	}
+	return newClientForHost(context.Background(), cfg, host, token)
+}
+
+func newClientForHost(ctx context.Context, cfg *config.Config, host, token string) (*Client, error) {
+	client, err := newGitHubClient(cfg.GetHostConfig(host), newHTTPClient(ctx, cfg, token))
+	if err != nil {
+		return nil, err
+	}
 
-	client := github.NewClient(tc)
-	
 	return &Client{
 		client: client,
 		ctx:    ctx,
@@ -78,36 +147,68 @@ func NewClient() (*Client, error) {
 // NewClientWithToken creates a new GitHub API client with provided token
 func NewClientWithToken(token string) *Client {
 	ctx := context.Background()
-	
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token},
-	)
-	tc := oauth2.NewClient(ctx, ts)
+	cfg := loadConfig()
+
+	httpClient := newHTTPClient(ctx, cfg, token)
+	client, err := newGitHubClient(cfg.GetHostConfig(""), httpClient)
+	if err != nil {
+		client = github.NewClient(httpClient)
+	}
 
-	client := github.NewClient(tc)
-	
 	return &Client{
 		client: client,
 		ctx:    ctx,
 	}
 }
 
+// loadConfig returns the user's configuration, or the defaults if it
+// cannot be read
+func loadConfig() *config.Config {
+	cfg, err := config.Load()
+	if err != nil {
+		return config.GetDefaultConfig()
+	}
+	return cfg
+}
+
+// newHTTPClient returns the HTTP client used to talk to the GitHub API.
+// Requests are authenticated with token, retried on transient failures and
+// answered from the on-disk cache where possible.
+func newHTTPClient(ctx context.Context, cfg *config.Config, token string) *http.Client {
+	var transport http.RoundTripper = http.DefaultTransport
+	if cacheDir, err := config.GetCacheDir(); err == nil {
+		offline := cfg.GitHub.Offline || os.Getenv("GITHUBBER_OFFLINE") != ""
+		transport = newCacheTransport(transport, cacheDir, offline)
+	}
+	transport = newRetryTransport(transport)
+
+	ts := oauth2.StaticTokenSource(
+		&oauth2.Token{AccessToken: token},
+	)
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: transport})
+	return oauth2.NewClient(ctx, ts)
+}
+
 // GetRepository gets repository information
 func (c *Client) GetRepository(owner, repo string) (*Repository, error) {
 	githubRepo, _, err := c.client.Repositories.Get(c.ctx, owner, repo)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get repository: %w", err)
+		return nil, fmt.Errorf("failed to get repository: %w", classifyError(err))
 	}
 
 	repository := &Repository{
-		Name:        githubRepo.GetName(),
-		Owner:       githubRepo.GetOwner().GetLogin(),
-		Description: githubRepo.GetDescription(),
-		URL:         githubRepo.GetHTMLURL(),
-		Private:     githubRepo.GetPrivate(),
-		Language:    githubRepo.GetLanguage(),
-		Stars:       githubRepo.GetStargazersCount(),
-		Forks:       githubRepo.GetForksCount(),
+		Name:          githubRepo.GetName(),
+		Owner:         githubRepo.GetOwner().GetLogin(),
+		Description:   githubRepo.GetDescription(),
+		URL:           githubRepo.GetHTMLURL(),
+		Private:       githubRepo.GetPrivate(),
+		Language:      githubRepo.GetLanguage(),
+		Stars:         githubRepo.GetStargazersCount(),
+		Forks:         githubRepo.GetForksCount(),
+		DefaultBranch: githubRepo.GetDefaultBranch(),
+
+		MergeMethods:        allowedMergeMethods(githubRepo),
+		DeleteBranchOnMerge: githubRepo.GetDeleteBranchOnMerge(),
 	}
 
 	return repository, nil
@@ -124,135 +225,149 @@ func (c *Client) CreatePullRequest(owner, repo, title, body, head, base string)
 
 	createdPR, _, err := c.client.PullRequests.Create(c.ctx, owner, repo, pr)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create pull request: %w", err)
+		return nil, fmt.Errorf("failed to create pull request: %w", classifyError(err))
 	}
 
-	pullRequest := &PullRequest{
-		Number: createdPR.GetNumber(),
-		Title:  createdPR.GetTitle(),
-		State:  createdPR.GetState(),
-		Author: createdPR.GetUser().GetLogin(),
-		URL:    createdPR.GetHTMLURL(),
-	}
+	return newPullRequest(createdPR), nil
+}
+
+// PullRequests returns a paginator over the pull requests of a repository
+func (c *Client) PullRequests(owner, repo, state string) *Paginator[*PullRequest] {
+	return NewPaginator(func(page github.ListOptions) ([]*PullRequest, *github.Response, error) {
+		opts := &github.PullRequestListOptions{
+			State:       state,
+			ListOptions: page,
+		}
+
+		prs, resp, err := c.client.PullRequests.List(c.ctx, owner, repo, opts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list pull requests: %w", classifyError(err))
+		}
 
-	return pullRequest, nil
+		pullRequests := make([]*PullRequest, 0, len(prs))
+		for _, pr := range prs {
+			pullRequests = append(pullRequests, newPullRequest(pr))
+		}
+
+		return pullRequests, resp, nil
+	}, 0)
 }
 
-// ListPullRequests lists pull requests for a repository
+// ListPullRequests lists all pull requests for a repository
 func (c *Client) ListPullRequests(owner, repo string, state string) ([]*PullRequest, error) {
-	opts := &github.PullRequestListOptions{
-		State: state,
-		ListOptions: github.ListOptions{
-			PerPage: 30,
-		},
-	}
+	return c.PullRequests(owner, repo, state).All()
+}
 
-	prs, _, err := c.client.PullRequests.List(c.ctx, owner, repo, opts)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list pull requests: %w", err)
-	}
+// Issues returns a paginator over the issues of a repository, excluding
+// pull requests
+func (c *Client) Issues(owner, repo, state string) *Paginator[*Issue] {
+	return NewPaginator(func(page github.ListOptions) ([]*Issue, *github.Response, error) {
+		opts := &github.IssueListByRepoOptions{
+			State:       state,
+			ListOptions: page,
+		}
 
-	var pullRequests []*PullRequest
-	for _, pr := range prs {
-		pullRequest := &PullRequest{
-			Number: pr.GetNumber(),
-			Title:  pr.GetTitle(),
-			State:  pr.GetState(),
-			Author: pr.GetUser().GetLogin(),
-			URL:    pr.GetHTMLURL(),
+		issues, resp, err := c.client.Issues.ListByRepo(c.ctx, owner, repo, opts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list issues: %w", classifyError(err))
+		}
+
+		issueList := make([]*Issue, 0, len(issues))
+		for _, issue := range issues {
+			// Skip pull requests (GitHub API treats PRs as issues)
+			if issue.IsPullRequest() {
+				continue
+			}
+
+			issueItem := &Issue{
+				Number: issue.GetNumber(),
+				Title:  issue.GetTitle(),
+				State:  issue.GetState(),
+				Author: issue.GetUser().GetLogin(),
+				URL:    issue.GetHTMLURL(),
+			}
+			issueList = append(issueList, issueItem)
 		}
-		pullRequests = append(pullRequests, pullRequest)
-	}
 
-	return pullRequests, nil
+		return issueList, resp, nil
+	}, 0)
 }
 
-// ListIssues lists issues for a repository
+// ListIssues lists all issues for a repository
 func (c *Client) ListIssues(owner, repo string, state string) ([]*Issue, error) {
-	opts := &github.IssueListByRepoOptions{
-		State: state,
-		ListOptions: github.ListOptions{
-			PerPage: 30,
-		},
-	}
-
-	issues, _, err := c.client.Issues.ListByRepo(c.ctx, owner, repo, opts)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list issues: %w", err)
-	}
+	return c.Issues(owner, repo, state).All()
+}
 
-	var issueList []*Issue
-	for _, issue := range issues {
-		// Skip pull requests (GitHub API treats PRs as issues)
-		if issue.IsPullRequest() {
-			continue
+// GetIssueLabels returns the label names of an issue or pull request
+func (c *Client) GetIssueLabels(owner, repo string, number int) ([]string, error) {
+	return NewPaginator(func(page github.ListOptions) ([]string, *github.Response, error) {
+		labels, resp, err := c.client.Issues.ListLabelsByIssue(c.ctx, owner, repo, number, &page)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get labels: %w", classifyError(err))
 		}
 
-		issueItem := &Issue{
-			Number: issue.GetNumber(),
-			Title:  issue.GetTitle(),
-			State:  issue.GetState(),
-			Author: issue.GetUser().GetLogin(),
-			URL:    issue.GetHTMLURL(),
+		names := make([]string, 0, len(labels))
+		for _, label := range labels {
+			names = append(names, label.GetName())
 		}
-		issueList = append(issueList, issueItem)
+
+		return names, resp, nil
+	}, 0).All()
+}
+
+// IsBranchProtected reports whether branch protection is enabled for branch
+func (c *Client) IsBranchProtected(owner, repo, branch string) (bool, error) {
+	githubBranch, _, err := c.client.Repositories.GetBranch(c.ctx, owner, repo, branch, 1)
+	if err != nil {
+		return false, fmt.Errorf("failed to get branch: %w", classifyError(err))
 	}
 
-	return issueList, nil
+	return githubBranch.GetProtected(), nil
 }
 
 // GetUser gets the authenticated user information
 func (c *Client) GetUser() (*github.User, error) {
 	user, _, err := c.client.Users.Get(c.ctx, "")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user: %w", err)
+		return nil, fmt.Errorf("failed to get user: %w", classifyError(err))
 	}
 	return user, nil
 }
 
+// RateLimits returns the remaining API quota of the authenticated user.
+// Querying the rate limit does not count against it.
+func (c *Client) RateLimits() (*RateLimits, error) {
+	limits, _, err := c.client.RateLimit.Get(c.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rate limits: %w", classifyError(err))
+	}
+
+	convert := func(rate *github.Rate) RateLimit {
+		if rate == nil {
+			return RateLimit{}
+		}
+		return RateLimit{Limit: rate.Limit, Remaining: rate.Remaining, Reset: rate.Reset.Time}
+	}
+
+	return &RateLimits{
+		Core:    convert(limits.GetCore()),
+		Search:  convert(limits.GetSearch()),
+		GraphQL: convert(limits.GetGraphQL()),
+	}, nil
+}
+
 // TestConnection tests the GitHub API connection
 func (c *Client) TestConnection() error {
 	_, err := c.GetUser()
 	return err
 }
 
-// Helper function to get GitHub CLI token
-func getGitHubCLIToken() string {
-	// This is a simplified approach - in a real implementation,
-	// you might want to parse the GitHub CLI config files
-	return ""
-}
-
-// ParseRepoURL parses a GitHub repository URL to extract owner and repo name
+// ParseRepoURL parses a repository URL to extract owner and repo name.
+// Any GitHub or GitHub Enterprise host is accepted; see ParseRepoRef.
 func ParseRepoURL(url string) (owner, repo string, err error) {
-	// Handle different URL formats:
-	// https://github.com/owner/repo
-	// https://github.com/owner/repo.git
-	// git@github.com:owner/repo.git
-	
-	url = strings.TrimSpace(url)
-	
-	if strings.HasPrefix(url, "git@github.com:") {
-		// SSH URL format
-		url = strings.TrimPrefix(url, "git@github.com:")
-		url = strings.TrimSuffix(url, ".git")
-		parts := strings.Split(url, "/")
-		if len(parts) != 2 {
-			return "", "", fmt.Errorf("invalid SSH URL format")
-		}
-		return parts[0], parts[1], nil
-	}
-	
-	if strings.HasPrefix(url, "https://github.com/") {
-		// HTTPS URL format
-		url = strings.TrimPrefix(url, "https://github.com/")
-		url = strings.TrimSuffix(url, ".git")
-		parts := strings.Split(url, "/")
-		if len(parts) != 2 {
-			return "", "", fmt.Errorf("invalid HTTPS URL format")
-		}
-		return parts[0], parts[1], nil
+	ref, err := ParseRepoRef(url)
+	if err != nil {
+		return "", "", err
 	}
-	
-	return "", "", fmt.Errorf("unsupported URL format")
-}
\ No newline at end of file
+	return ref.Owner, ref.Name, nil
+}
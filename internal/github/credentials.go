@@ -0,0 +1,337 @@
+/*
+ * GitHubber - GitHub Credentials
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Token lookup across environment, config, gh CLI, git credential helpers and keyring
+ */
+
+package github
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ritankarsaha/git-tool/internal/config"
+)
+
+// KeyringService is the Secret Service "service" attribute GitHubber
+// stores tokens under, next to a "host" attribute
+const KeyringService = "githubber"
+
+// credentialTimeout bounds external helpers so an interactive credential
+// helper cannot hang the menu
+const credentialTimeout = 5 * time.Second
+
+// errNoToken is returned by sources that were consulted but had no token
+var errNoToken = errors.New("no token")
+
+// Credential is a token for a host and the source that supplied it
+type Credential struct {
+	Host   string
+	Token  string
+	Source string
+}
+
+// CredentialCheck is the outcome of consulting one credential source
+type CredentialCheck struct {
+	Source string
+	Found  bool
+	Err    error // Set when the source could not be consulted
+}
+
+type credentialSource struct {
+	name   string
+	lookup func() (string, error)
+}
+
+// credentialSources returns the token sources for host in order of
+// precedence: environment variables, the config file, the gh CLI, git
+// credential helpers and the Secret Service keyring
+func credentialSources(cfg *config.Config, host string) []credentialSource {
+	var envVars []string
+	if host == "github.com" {
+		envVars = []string{"GITHUB_TOKEN", "GH_TOKEN"}
+	} else {
+		envVars = []string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"}
+		if host == cfg.DefaultHost() {
+			// GITHUB_TOKEN has always applied to the configured API
+			envVars = append(envVars, "GITHUB_TOKEN")
+		}
+	}
+
+	var sources []credentialSource
+	for _, name := range envVars {
+		sources = append(sources, credentialSource{
+			name:   name + " environment variable",
+			lookup: func() (string, error) { return envToken(name) },
+		})
+	}
+
+	return append(sources,
+		credentialSource{"GitHubber config file", func() (string, error) { return configToken(cfg, host) }},
+		credentialSource{"gh CLI hosts.yml", func() (string, error) { return ghCLIToken(host) }},
+		credentialSource{"git credential helper", func() (string, error) { return gitCredentialToken(host) }},
+		credentialSource{"Secret Service keyring", func() (string, error) { return keyringToken(host) }},
+	)
+}
+
+// ResolveCredential returns the token for host (the default host if empty)
+// from the first source that has one
+func ResolveCredential(host string) (*Credential, error) {
+	cfg := loadConfig()
+	if host == "" {
+		host = cfg.DefaultHost()
+	}
+	return resolveCredential(cfg, host)
+}
+
+func resolveCredential(cfg *config.Config, host string) (*Credential, error) {
+	for _, source := range credentialSources(cfg, host) {
+		if token, err := source.lookup(); err == nil {
+			return &Credential{Host: host, Token: token, Source: source.name}, nil
+		}
+	}
+	return nil, fmt.Errorf("no GitHub token found for %s", host)
+}
+
+// DiagnoseCredentials consults every credential source for host (the
+// default host if empty). It returns the credential that would be used, or
+// nil, along with the outcome of each source.
+func DiagnoseCredentials(host string) (*Credential, []CredentialCheck) {
+	cfg := loadConfig()
+	if host == "" {
+		host = cfg.DefaultHost()
+	}
+
+	var credential *Credential
+	var checks []CredentialCheck
+	for _, source := range credentialSources(cfg, host) {
+		token, err := source.lookup()
+		check := CredentialCheck{Source: source.name, Found: err == nil}
+		if err != nil && !errors.Is(err, errNoToken) {
+			check.Err = err
+		}
+		if err == nil && credential == nil {
+			credential = &Credential{Host: host, Token: token, Source: source.name}
+		}
+		checks = append(checks, check)
+	}
+	return credential, checks
+}
+
+// MaskToken hides all but the ends of a token for display
+func MaskToken(token string) string {
+	if len(token) < 12 {
+		return strings.Repeat("*", len(token))
+	}
+	return token[:4] + strings.Repeat("*", len(token)-8) + token[len(token)-4:]
+}
+
+func envToken(name string) (string, error) {
+	if token := strings.TrimSpace(os.Getenv(name)); token != "" {
+		return token, nil
+	}
+	return "", errNoToken
+}
+
+func configToken(cfg *config.Config, host string) (string, error) {
+	if token := cfg.GetHostConfig(host).Token; token != "" {
+		return token, nil
+	}
+	return "", errNoToken
+}
+
+// ghConfigDir returns the directory the gh CLI keeps its configuration in
+func ghConfigDir() (string, error) {
+	if dir := os.Getenv("GH_CONFIG_DIR"); dir != "" {
+		return dir, nil
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh"), nil
+	}
+	if dir := os.Getenv("AppData"); dir != "" {
+		return filepath.Join(dir, "GitHub CLI"), nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".config", "gh"), nil
+}
+
+// ghCLIToken reads the token gh stored for host in hosts.yml. Recent gh
+// versions keep tokens in the keyring instead; keyringToken covers those.
+func ghCLIToken(host string) (string, error) {
+	dir, err := ghConfigDir()
+	if err != nil {
+		return "", err
+	}
+	file, err := os.Open(filepath.Join(dir, "hosts.yml"))
+	if os.IsNotExist(err) {
+		return "", errNoToken
+	}
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	token := parseGHHosts(bufio.NewScanner(file), host)
+	if token == "" {
+		return "", errNoToken
+	}
+	return token, nil
+}
+
+// parseGHHosts extracts the oauth_token of host from gh's hosts.yml:
+//
+//	github.com:
+//	    user: octocat
+//	    oauth_token: gho_xxx
+//	    users:
+//	        octocat:
+//	            oauth_token: gho_xxx
+//
+// This only understands the block mappings gh writes, not YAML in general.
+func parseGHHosts(scanner *bufio.Scanner, host string) string {
+	var (
+		inHost     bool
+		hostIndent = -1 // Indentation of the host's own keys
+		path       []string
+		indents    []int
+		user       string
+		token      string
+		userTokens = make(map[string]string)
+	)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		key, value, _ := strings.Cut(trimmed, ":")
+		key = strings.Trim(strings.TrimSpace(key), `"'`)
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+
+		if indent == 0 {
+			inHost = strings.EqualFold(key, host)
+			hostIndent, path, indents = -1, nil, nil
+			continue
+		}
+		if !inHost {
+			continue
+		}
+		if hostIndent < 0 {
+			hostIndent = indent
+		}
+
+		for len(indents) > 0 && indents[len(indents)-1] >= indent {
+			path, indents = path[:len(path)-1], indents[:len(indents)-1]
+		}
+		if value == "" {
+			path, indents = append(path, key), append(indents, indent)
+			continue
+		}
+
+		switch {
+		case indent == hostIndent && key == "oauth_token":
+			token = value
+		case indent == hostIndent && key == "user":
+			user = value
+		case len(path) == 2 && path[0] == "users" && key == "oauth_token":
+			userTokens[path[1]] = value
+		}
+	}
+
+	if token != "" {
+		return token
+	}
+	return userTokens[user]
+}
+
+// gitCredentialToken asks git's configured credential helpers for the
+// password stored for https://host without prompting the user
+func gitCredentialToken(host string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), credentialTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", "credential", "fill")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("protocol=https\nhost=%s\n\n", host))
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GCM_INTERACTIVE=never", "GIT_ASKPASS=", "SSH_ASKPASS=")
+	output, err := cmd.Output()
+	if err != nil {
+		// git exits non-zero when no helper has credentials and prompting is disabled
+		if _, ok := err.(*exec.ExitError); ok {
+			return "", errNoToken
+		}
+		return "", err
+	}
+
+	for _, line := range strings.Split(string(output), "\n") {
+		if password, ok := strings.CutPrefix(line, "password="); ok && password != "" {
+			return password, nil
+		}
+	}
+	return "", errNoToken
+}
+
+// keyringToken looks up a token for host in the Secret Service keyring,
+// first under GitHubber's own entry, then under the entry gh creates
+func keyringToken(host string) (string, error) {
+	if !KeyringAvailable() {
+		return "", fmt.Errorf("secret-tool is not installed")
+	}
+
+	for _, attributes := range [][]string{
+		{"service", KeyringService, "host", host},
+		{"service", "gh:" + host},
+	} {
+		ctx, cancel := context.WithTimeout(context.Background(), credentialTimeout)
+		output, err := exec.CommandContext(ctx, "secret-tool", append([]string{"lookup"}, attributes...)...).Output()
+		cancel()
+		if token := strings.TrimSpace(string(output)); err == nil && token != "" {
+			return token, nil
+		}
+	}
+	return "", errNoToken
+}
+
+// KeyringAvailable reports whether tokens can be kept in the Secret
+// Service keyring
+func KeyringAvailable() bool {
+	_, err := exec.LookPath("secret-tool")
+	return err == nil
+}
+
+// StoreKeyringToken saves token for host in the Secret Service keyring
+func StoreKeyringToken(host, token string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), credentialTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "secret-tool", "store", "--label", "GitHubber token for "+host,
+		"service", KeyringService, "host", host)
+	cmd.Stdin = strings.NewReader(token)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to store token in keyring: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// DeleteKeyringToken removes GitHubber's token for host from the keyring
+func DeleteKeyringToken(host string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), credentialTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "secret-tool", "clear", "service", KeyringService, "host", host)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to remove token from keyring: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package github
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ritankarsaha/git-tool/internal/config"
+)
+
+// isolateCredentials points every credential source at empty temporary
+// locations
+func isolateCredentials(t *testing.T) (ghDir string) {
+	t.Helper()
+	for _, name := range []string{"GITHUB_TOKEN", "GH_TOKEN", "GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"} {
+		t.Setenv(name, "")
+	}
+	t.Setenv("HOME", t.TempDir())
+	ghDir = t.TempDir()
+	t.Setenv("GH_CONFIG_DIR", ghDir)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_COUNT", "0")
+	return ghDir
+}
+
+func resolvedSource(t *testing.T, host string) (token, source string) {
+	t.Helper()
+	credential, err := ResolveCredential(host)
+	if err != nil {
+		t.Fatalf("ResolveCredential(%q) error = %v", host, err)
+	}
+	return credential.Token, credential.Source
+}
+
+func TestResolveCredentialPrecedence(t *testing.T) {
+	ghDir := isolateCredentials(t)
+
+	// Lowest precedence first, checking each source takes over
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+	t.Setenv("GIT_CONFIG_KEY_0", "credential.helper")
+	t.Setenv("GIT_CONFIG_VALUE_0", "!f() { echo username=x-access-token; echo password=helper-token; }; f")
+	if token, source := resolvedSource(t, "github.com"); token != "helper-token" || source != "git credential helper" {
+		t.Errorf("got %q from %s, want helper-token from git credential helper", token, source)
+	}
+
+	hosts := "github.com:\n    user: octocat\n    oauth_token: gh-token\n    git_protocol: https\n"
+	if err := os.WriteFile(filepath.Join(ghDir, "hosts.yml"), []byte(hosts), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if token, source := resolvedSource(t, "github.com"); token != "gh-token" || source != "gh CLI hosts.yml" {
+		t.Errorf("got %q from %s, want gh-token from gh CLI hosts.yml", token, source)
+	}
+
+	cfg := config.GetDefaultConfig()
+	cfg.GitHub.Token = "config-token"
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if token, source := resolvedSource(t, "github.com"); token != "config-token" || source != "GitHubber config file" {
+		t.Errorf("got %q from %s, want config-token from the config file", token, source)
+	}
+
+	t.Setenv("GH_TOKEN", "gh-env-token")
+	if token, _ := resolvedSource(t, "github.com"); token != "gh-env-token" {
+		t.Errorf("got %q, want GH_TOKEN", token)
+	}
+	t.Setenv("GITHUB_TOKEN", "github-env-token")
+	if token, source := resolvedSource(t, ""); token != "github-env-token" || source != "GITHUB_TOKEN environment variable" {
+		t.Errorf("got %q from %s, want GITHUB_TOKEN", token, source)
+	}
+}
+
+func TestResolveCredentialEnterpriseHost(t *testing.T) {
+	isolateCredentials(t)
+	t.Setenv("GITHUB_TOKEN", "github-env-token")
+
+	cfg := config.GetDefaultConfig()
+	cfg.GitHub.Hosts = map[string]config.HostConfig{"ghe.example.com": {Token: "ghe-config-token"}}
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if token, _ := resolvedSource(t, "ghe.example.com"); token != "ghe-config-token" {
+		t.Errorf("got %q, want the host's config token", token)
+	}
+
+	t.Setenv("GH_ENTERPRISE_TOKEN", "ghe-env-token")
+	if token, _ := resolvedSource(t, "ghe.example.com"); token != "ghe-env-token" {
+		t.Errorf("got %q, want GH_ENTERPRISE_TOKEN", token)
+	}
+}
+
+func TestDiagnoseCredentials(t *testing.T) {
+	isolateCredentials(t)
+	t.Setenv("GH_TOKEN", "gh-env-token")
+
+	credential, checks := DiagnoseCredentials("github.com")
+	if credential == nil || credential.Source != "GH_TOKEN environment variable" {
+		t.Fatalf("credential = %+v, want GH_TOKEN", credential)
+	}
+	if len(checks) != 6 || checks[0].Found || !checks[1].Found {
+		t.Errorf("checks = %+v", checks)
+	}
+
+	if _, err := exec.LookPath("secret-tool"); err == nil {
+		t.Skip("a keyring is available, cannot check the no-token case")
+	}
+	t.Setenv("GH_TOKEN", "")
+	if _, err := ResolveCredential("github.com"); err == nil {
+		t.Error("ResolveCredential() succeeded without any token")
+	}
+}
+
+func TestParseGHHosts(t *testing.T) {
+	hosts := `github.com:
+    users:
+        octocat:
+            oauth_token: gho_user
+        other:
+            oauth_token: gho_other
+    git_protocol: ssh
+    user: octocat
+ghe.example.com:
+    oauth_token: "gho_enterprise"
+    user: admin
+`
+	tests := map[string]string{
+		"github.com":      "gho_user",
+		"ghe.example.com": "gho_enterprise",
+		"missing.example": "",
+	}
+	for host, want := range tests {
+		if got := parseGHHosts(bufio.NewScanner(strings.NewReader(hosts)), host); got != want {
+			t.Errorf("parseGHHosts(%q) = %q, want %q", host, got, want)
+		}
+	}
+}
+
+func TestMaskToken(t *testing.T) {
+	if got := MaskToken("ghp_abcdefghijklmnop"); got != "ghp_************mnop" {
+		t.Errorf("MaskToken() = %q", got)
+	}
+	if got := MaskToken("short"); got != "*****" {
+		t.Errorf("MaskToken(short) = %q", got)
+	}
+}
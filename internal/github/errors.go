@@ -0,0 +1,178 @@
+/*
+ * GitHubber - GitHub API Errors
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Typed errors for common GitHub API failure modes
+ */
+
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// NotFoundError is returned when a resource does not exist or is not
+// visible with the current credentials
+type NotFoundError struct {
+	Message string
+	Err     error
+}
+
+func (e *NotFoundError) Error() string {
+	return "not found: " + e.Message
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// UnauthorizedError is returned when the token is missing, invalid or
+// lacks permission for the request
+type UnauthorizedError struct {
+	Message   string
+	Forbidden bool // Authenticated but not allowed (403) rather than 401
+	Err       error
+}
+
+func (e *UnauthorizedError) Error() string {
+	if e.Forbidden {
+		return "forbidden: " + e.Message
+	}
+	return "unauthorized: " + e.Message
+}
+
+func (e *UnauthorizedError) Unwrap() error {
+	return e.Err
+}
+
+// RateLimitedError is returned when the primary or a secondary rate limit
+// has been exceeded
+type RateLimitedError struct {
+	Reset     time.Time // When requests are allowed again
+	Secondary bool
+	Err       error
+}
+
+func (e *RateLimitedError) Error() string {
+	kind := "rate limit"
+	if e.Secondary {
+		kind = "secondary rate limit"
+	}
+	if e.Reset.IsZero() {
+		return kind + " exceeded"
+	}
+	return fmt.Sprintf("%s exceeded, resets at %s", kind, e.Reset.Format("15:04:05"))
+}
+
+func (e *RateLimitedError) Unwrap() error {
+	return e.Err
+}
+
+// FieldError describes a single invalid field of a rejected request
+type FieldError struct {
+	Resource string
+	Field    string
+	Code     string
+	Message  string
+}
+
+// ValidationError is returned when GitHub rejects a request as invalid (422)
+type ValidationError struct {
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+func (e *ValidationError) Error() string {
+	details := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		switch {
+		case field.Message != "":
+			details = append(details, field.Message)
+		case field.Field != "":
+			details = append(details, fmt.Sprintf("%s.%s: %s", field.Resource, field.Field, field.Code))
+		default:
+			details = append(details, field.Code)
+		}
+	}
+	if len(details) == 0 {
+		return "validation failed: " + e.Message
+	}
+	return fmt.Sprintf("validation failed: %s (%s)", e.Message, strings.Join(details, "; "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ConflictError is returned when a request conflicts with the current state
+// of a resource, such as merging a pull request that is not mergeable (405)
+// or whose head branch has moved (409)
+type ConflictError struct {
+	Message string
+	Err     error
+}
+
+func (e *ConflictError) Error() string {
+	return "conflict: " + e.Message
+}
+
+func (e *ConflictError) Unwrap() error {
+	return e.Err
+}
+
+// classifyError converts go-github errors into the typed errors above.
+// Other errors are returned unchanged.
+func classifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var rateLimitErr *github.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return &RateLimitedError{Reset: rateLimitErr.Rate.Reset.Time, Err: err}
+	}
+
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		limited := &RateLimitedError{Secondary: true, Err: err}
+		if abuseErr.RetryAfter != nil {
+			limited.Reset = time.Now().Add(*abuseErr.RetryAfter)
+		}
+		return limited
+	}
+
+	var responseErr *github.ErrorResponse
+	if !errors.As(err, &responseErr) || responseErr.Response == nil {
+		return err
+	}
+
+	switch responseErr.Response.StatusCode {
+	case http.StatusNotFound:
+		return &NotFoundError{Message: responseErr.Message, Err: err}
+	case http.StatusUnauthorized:
+		return &UnauthorizedError{Message: responseErr.Message, Err: err}
+	case http.StatusForbidden:
+		return &UnauthorizedError{Message: responseErr.Message, Forbidden: true, Err: err}
+	case http.StatusMethodNotAllowed, http.StatusConflict:
+		return &ConflictError{Message: responseErr.Message, Err: err}
+	case http.StatusUnprocessableEntity:
+		validation := &ValidationError{Message: responseErr.Message, Err: err}
+		for _, fieldErr := range responseErr.Errors {
+			validation.Fields = append(validation.Fields, FieldError{
+				Resource: fieldErr.Resource,
+				Field:    fieldErr.Field,
+				Code:     fieldErr.Code,
+				Message:  fieldErr.Message,
+			})
+		}
+		return validation
+	}
+
+	return err
+}
@@ -0,0 +1,128 @@
+/*
+ * GitHubber - GitHub Hosts
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Repository URL parsing and per-host API endpoints for GitHub Enterprise
+ */
+
+package github
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/ritankarsaha/git-tool/internal/config"
+)
+
+// RepoRef identifies a repository on a GitHub host
+type RepoRef struct {
+	Host  string // e.g. "github.com" or "github.example.com:8443"
+	Owner string
+	Name  string
+}
+
+// String returns the repository as "owner/name"
+func (r *RepoRef) String() string {
+	return r.Owner + "/" + r.Name
+}
+
+// WebURL returns the repository's web page
+func (r *RepoRef) WebURL() string {
+	return fmt.Sprintf("https://%s/%s/%s", r.Host, r.Owner, r.Name)
+}
+
+// ParseRepoRef parses a repository remote URL on any GitHub host. Supported
+// formats are http(s):// and git:// URLs, ssh:// URLs with optional user and
+// port, and scp-like [user@]host:owner/repo paths. The port of SSH URLs is
+// dropped since it has nothing to do with the host's API.
+func ParseRepoRef(remoteURL string) (*RepoRef, error) {
+	remoteURL = strings.TrimSpace(remoteURL)
+	if remoteURL == "" {
+		return nil, fmt.Errorf("empty repository URL")
+	}
+
+	var host, path string
+	if strings.Contains(remoteURL, "://") {
+		parsed, err := url.Parse(remoteURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid repository URL: %w", err)
+		}
+		switch parsed.Scheme {
+		case "https", "http":
+			host = parsed.Host
+		case "ssh", "git", "git+ssh", "ssh+git":
+			host = parsed.Hostname()
+		default:
+			return nil, fmt.Errorf("unsupported URL scheme: %s", parsed.Scheme)
+		}
+		path = parsed.Path
+	} else {
+		// scp-like syntax: [user@]host:owner/repo.git
+		colon := strings.Index(remoteURL, ":")
+		if colon < 0 || strings.Contains(remoteURL[:colon], "/") {
+			return nil, fmt.Errorf("unsupported URL format")
+		}
+		host = remoteURL[:colon]
+		if at := strings.LastIndex(host, "@"); at >= 0 {
+			host = host[at+1:]
+		}
+		path = remoteURL[colon+1:]
+	}
+
+	host = strings.ToLower(host)
+	if host == "" {
+		return nil, fmt.Errorf("repository URL has no host")
+	}
+	if hostname, port, err := net.SplitHostPort(host); err == nil && (port == "443" || port == "80") {
+		host = hostname
+	}
+	if host == "ssh.github.com" || host == "www.github.com" {
+		host = "github.com"
+	}
+
+	path = strings.Trim(path, "/")
+	path = strings.TrimSuffix(path, ".git")
+	parts := strings.Split(path, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("repository URL must point to owner/repo: %s", remoteURL)
+	}
+
+	return &RepoRef{Host: host, Owner: parts[0], Name: parts[1]}, nil
+}
+
+// newGitHubClient returns a go-github client for the API endpoints of
+// hostConfig, using the Enterprise endpoints for anything but api.github.com
+func newGitHubClient(hostConfig config.HostConfig, httpClient *http.Client) (*github.Client, error) {
+	client := github.NewClient(httpClient)
+	baseURL := strings.TrimSuffix(hostConfig.APIBaseURL, "/")
+	if baseURL == "" || baseURL == "https://api.github.com" {
+		return client, nil
+	}
+
+	uploadURL := hostConfig.UploadURL
+	if uploadURL == "" {
+		base, err := url.Parse(baseURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid API base URL: %w", err)
+		}
+		uploadURL = fmt.Sprintf("%s://%s/api/uploads/", base.Scheme, base.Host)
+	}
+
+	client, err := client.WithEnterpriseURLs(hostConfig.APIBaseURL, uploadURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GitHub Enterprise URLs: %w", err)
+	}
+	if hostConfig.UploadURL != "" {
+		// WithEnterpriseURLs appends api/uploads/, which is wrong for
+		// servers with a dedicated uploads host
+		upload, err := url.Parse(strings.TrimSuffix(hostConfig.UploadURL, "/") + "/")
+		if err != nil {
+			return nil, fmt.Errorf("invalid upload URL: %w", err)
+		}
+		client.UploadURL = upload
+	}
+	return client, nil
+}
@@ -0,0 +1,257 @@
+/*
+ * GitHubber - GitHub Pull Request Merging
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Merging pull requests, merge readiness checks and head branch deletion
+ */
+
+package github
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// Merge methods accepted by MergePullRequest
+const (
+	MergeMethodMerge  = "merge"
+	MergeMethodSquash = "squash"
+	MergeMethodRebase = "rebase"
+)
+
+// MergeMethods lists all merge methods in GitHub's order of preference
+var MergeMethods = []string{MergeMethodMerge, MergeMethodSquash, MergeMethodRebase}
+
+// MergeOptions controls how a pull request is merged
+type MergeOptions struct {
+	Method        string
+	CommitTitle   string // Merge and squash commits only; GitHub's default if empty
+	CommitMessage string // Merge and squash commits only; GitHub's default if empty
+	SHA           string // Head commit the merge is for; fails if the branch moved since
+}
+
+// MergeResult describes a merged pull request
+type MergeResult struct {
+	SHA     string // The merge, squash or last rebased commit on the base branch
+	Message string
+}
+
+// MergeRequirements are the branch protection rules that apply to merging
+// into a branch
+type MergeRequirements struct {
+	RequiredApprovals int
+	RequiredChecks    []string
+	Strict            bool // The head must be up to date with the base branch
+}
+
+// MergeCheck lists what stands in the way of merging a pull request.
+// Blockers make GitHub refuse the merge (unless an administrator bypasses
+// branch protection); warnings are worth confirming first.
+type MergeCheck struct {
+	Blockers []string
+	Warnings []string
+}
+
+// Ready reports whether nothing blocks the merge
+func (m *MergeCheck) Ready() bool {
+	return len(m.Blockers) == 0
+}
+
+// allowedMergeMethods returns the merge methods a repository allows. GitHub
+// only reports these settings to users with write access, so all methods
+// are returned when none is reported.
+func allowedMergeMethods(repo *github.Repository) []string {
+	allowed := map[string]*bool{
+		MergeMethodMerge:  repo.AllowMergeCommit,
+		MergeMethodSquash: repo.AllowSquashMerge,
+		MergeMethodRebase: repo.AllowRebaseMerge,
+	}
+
+	var methods []string
+	for _, method := range MergeMethods {
+		if allowed[method] != nil && *allowed[method] {
+			methods = append(methods, method)
+		}
+	}
+	if len(methods) == 0 {
+		return MergeMethods
+	}
+	return methods
+}
+
+// MergePullRequest merges a pull request using opts.Method
+func (c *Client) MergePullRequest(owner, repo string, number int, opts MergeOptions) (*MergeResult, error) {
+	options := &github.PullRequestOptions{
+		CommitTitle: opts.CommitTitle,
+		SHA:         opts.SHA,
+		MergeMethod: opts.Method,
+	}
+	if opts.Method == MergeMethodRebase {
+		// Rebase merges do not create a commit to describe
+		options.CommitTitle = ""
+		opts.CommitMessage = ""
+	}
+
+	result, _, err := c.client.PullRequests.Merge(c.ctx, owner, repo, number, opts.CommitMessage, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge pull request: %w", classifyError(err))
+	}
+	if !result.GetMerged() {
+		return nil, fmt.Errorf("failed to merge pull request: %s", result.GetMessage())
+	}
+
+	return &MergeResult{SHA: result.GetSHA(), Message: result.GetMessage()}, nil
+}
+
+// DeleteBranch deletes a branch of a repository on GitHub
+func (c *Client) DeleteBranch(owner, repo, branch string) error {
+	_, err := c.client.Git.DeleteRef(c.ctx, owner, repo, "heads/"+branch)
+	if err != nil {
+		return fmt.Errorf("failed to delete branch %s: %w", branch, classifyError(err))
+	}
+	return nil
+}
+
+// MergeRequirements returns the reviews and status checks branch protection
+// requires before merging into branch. Reading branch protection needs
+// administrator access; other users get an *UnauthorizedError or
+// *NotFoundError.
+func (c *Client) MergeRequirements(owner, repo, branch string) (*MergeRequirements, error) {
+	protection, _, err := c.client.Repositories.GetBranchProtection(c.ctx, owner, repo, branch)
+	if errors.Is(err, github.ErrBranchNotProtected) {
+		return &MergeRequirements{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branch protection: %w", classifyError(err))
+	}
+
+	requirements := &MergeRequirements{}
+	if reviews := protection.GetRequiredPullRequestReviews(); reviews != nil {
+		requirements.RequiredApprovals = reviews.RequiredApprovingReviewCount
+	}
+	if checks := protection.GetRequiredStatusChecks(); checks != nil {
+		requirements.Strict = checks.Strict
+		if checks.Checks != nil {
+			for _, check := range *checks.Checks {
+				requirements.RequiredChecks = append(requirements.RequiredChecks, check.Context)
+			}
+		} else if checks.Contexts != nil {
+			requirements.RequiredChecks = append(requirements.RequiredChecks, *checks.Contexts...)
+		}
+	}
+	return requirements, nil
+}
+
+// CheckMerge reports what stands in the way of merging pr. Without access
+// to the base branch's protection rules, GitHub's mergeable state is used
+// to detect missing reviews and checks.
+func (c *Client) CheckMerge(owner, repo string, pr *PullRequestDetail) (*MergeCheck, error) {
+	requirements, err := c.MergeRequirements(owner, repo, pr.Base)
+	if err != nil {
+		var notFound *NotFoundError
+		var unauthorized *UnauthorizedError
+		if !errors.As(err, &notFound) && !errors.As(err, &unauthorized) {
+			return nil, err
+		}
+		requirements = nil
+	}
+	return evaluateMerge(pr, requirements), nil
+}
+
+// evaluateMerge checks pr against requirements, which is nil when the
+// branch protection rules are unknown
+func evaluateMerge(pr *PullRequestDetail, requirements *MergeRequirements) *MergeCheck {
+	check := &MergeCheck{}
+	switch {
+	case pr.Merged:
+		check.Blockers = append(check.Blockers, "The pull request is already merged")
+		return check
+	case pr.State != "open":
+		check.Blockers = append(check.Blockers, "The pull request is closed")
+		return check
+	}
+
+	if pr.Draft {
+		check.Blockers = append(check.Blockers, "The pull request is still a draft")
+	}
+	switch {
+	case pr.Mergeable == nil:
+		check.Warnings = append(check.Warnings, "GitHub has not finished checking for merge conflicts")
+	case !*pr.Mergeable:
+		check.Blockers = append(check.Blockers, fmt.Sprintf("The branch has merge conflicts with %s", pr.Base))
+	}
+
+	approvals := 0
+	var changesRequested []string
+	for _, review := range pr.Reviews {
+		switch review.State {
+		case "APPROVED":
+			approvals++
+		case "CHANGES_REQUESTED":
+			changesRequested = append(changesRequested, review.Reviewer)
+		}
+	}
+	if len(changesRequested) > 0 {
+		check.Blockers = append(check.Blockers, fmt.Sprintf("Changes requested by %s", strings.Join(changesRequested, ", ")))
+	}
+	if requirements != nil && approvals < requirements.RequiredApprovals {
+		check.Blockers = append(check.Blockers, fmt.Sprintf("%d of %d required approving reviews", approvals, requirements.RequiredApprovals))
+	}
+
+	required := make(map[string]bool)
+	if requirements != nil {
+		for _, name := range requirements.RequiredChecks {
+			required[name] = true
+		}
+	}
+	reported := make(map[string]bool)
+	var failing, pending, failingOptional []string
+	for _, result := range pr.Checks {
+		reported[result.Name] = true
+		switch CombinedCheckState([]*CheckResult{result}) {
+		case "failure":
+			// Unknown protection rules make every check potentially required
+			if requirements == nil || required[result.Name] {
+				failing = append(failing, result.Name)
+			} else {
+				failingOptional = append(failingOptional, result.Name)
+			}
+		case "pending":
+			pending = append(pending, result.Name)
+		}
+	}
+	var missing []string
+	if requirements != nil {
+		for _, name := range requirements.RequiredChecks {
+			if !reported[name] {
+				missing = append(missing, name)
+			}
+		}
+	}
+	if len(failing) > 0 {
+		check.Blockers = append(check.Blockers, fmt.Sprintf("Failing checks: %s", strings.Join(failing, ", ")))
+	}
+	if len(missing) > 0 {
+		check.Blockers = append(check.Blockers, fmt.Sprintf("Required checks have not run: %s", strings.Join(missing, ", ")))
+	}
+	if len(failingOptional) > 0 {
+		check.Warnings = append(check.Warnings, fmt.Sprintf("Failing optional checks: %s", strings.Join(failingOptional, ", ")))
+	}
+	if len(pending) > 0 {
+		check.Warnings = append(check.Warnings, fmt.Sprintf("Checks still running: %s", strings.Join(pending, ", ")))
+	}
+
+	if pr.MergeableState == "behind" {
+		if requirements != nil && requirements.Strict {
+			check.Blockers = append(check.Blockers, fmt.Sprintf("The branch must be updated with %s before merging", pr.Base))
+		} else {
+			check.Warnings = append(check.Warnings, fmt.Sprintf("The branch is behind %s", pr.Base))
+		}
+	}
+	if pr.MergeableState == "blocked" && check.Ready() {
+		check.Blockers = append(check.Blockers, "Branch protection rules block the merge (required reviews or checks are missing)")
+	}
+	return check
+}
@@ -0,0 +1,204 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v66/github"
+)
+
+func TestAllowedMergeMethods(t *testing.T) {
+	repo := &github.Repository{
+		AllowMergeCommit: github.Bool(false),
+		AllowSquashMerge: github.Bool(true),
+		AllowRebaseMerge: github.Bool(true),
+	}
+	if got := allowedMergeMethods(repo); !reflect.DeepEqual(got, []string{"squash", "rebase"}) {
+		t.Errorf("allowedMergeMethods() = %v, want [squash rebase]", got)
+	}
+	if got := allowedMergeMethods(&github.Repository{}); !reflect.DeepEqual(got, MergeMethods) {
+		t.Errorf("allowedMergeMethods() without settings = %v, want all methods", got)
+	}
+}
+
+func TestEvaluateMerge(t *testing.T) {
+	mergeable := true
+	conflicted := false
+	newPR := func() *PullRequestDetail {
+		return &PullRequestDetail{PullRequest: &PullRequest{
+			State: "open", Base: "main", Mergeable: &mergeable, MergeableState: "clean",
+		}}
+	}
+
+	tests := []struct {
+		name         string
+		modify       func(pr *PullRequestDetail)
+		requirements *MergeRequirements
+		blockers     []string
+		warnings     []string
+	}{
+		{
+			name:         "ready",
+			modify:       func(pr *PullRequestDetail) {},
+			requirements: &MergeRequirements{},
+		},
+		{
+			name:     "merged",
+			modify:   func(pr *PullRequestDetail) { pr.Merged = true; pr.State = "closed" },
+			blockers: []string{"The pull request is already merged"},
+		},
+		{
+			name: "draft with conflicts",
+			modify: func(pr *PullRequestDetail) {
+				pr.Draft = true
+				pr.Mergeable = &conflicted
+				pr.MergeableState = "dirty"
+			},
+			blockers: []string{"The pull request is still a draft", "The branch has merge conflicts with main"},
+		},
+		{
+			name: "reviews",
+			modify: func(pr *PullRequestDetail) {
+				pr.Reviews = []*Review{{Reviewer: "alice", State: "APPROVED"}, {Reviewer: "bob", State: "CHANGES_REQUESTED"}}
+			},
+			requirements: &MergeRequirements{RequiredApprovals: 2},
+			blockers:     []string{"Changes requested by bob", "1 of 2 required approving reviews"},
+		},
+		{
+			name: "required and optional checks",
+			modify: func(pr *PullRequestDetail) {
+				pr.Checks = []*CheckResult{
+					{Name: "build", State: "failure"},
+					{Name: "lint", State: "failure"},
+					{Name: "test", State: "in_progress"},
+				}
+				pr.MergeableState = "behind"
+			},
+			requirements: &MergeRequirements{RequiredChecks: []string{"build", "deploy"}, Strict: true},
+			blockers: []string{
+				"Failing checks: build",
+				"Required checks have not run: deploy",
+				"The branch must be updated with main before merging",
+			},
+			warnings: []string{"Failing optional checks: lint", "Checks still running: test"},
+		},
+		{
+			name: "unknown protection rules",
+			modify: func(pr *PullRequestDetail) {
+				pr.Mergeable = nil
+				pr.MergeableState = "blocked"
+				pr.Checks = []*CheckResult{{Name: "lint", State: "failure"}}
+			},
+			blockers: []string{"Failing checks: lint"},
+			warnings: []string{"GitHub has not finished checking for merge conflicts"},
+		},
+		{
+			name:     "blocked without known cause",
+			modify:   func(pr *PullRequestDetail) { pr.MergeableState = "blocked" },
+			blockers: []string{"Branch protection rules block the merge (required reviews or checks are missing)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := newPR()
+			tt.modify(pr)
+			check := evaluateMerge(pr, tt.requirements)
+			if !reflect.DeepEqual(check.Blockers, tt.blockers) {
+				t.Errorf("blockers = %q, want %q", check.Blockers, tt.blockers)
+			}
+			if !reflect.DeepEqual(check.Warnings, tt.warnings) {
+				t.Errorf("warnings = %q, want %q", check.Warnings, tt.warnings)
+			}
+			if check.Ready() != (len(tt.blockers) == 0) {
+				t.Errorf("Ready() = %v", check.Ready())
+			}
+		})
+	}
+}
+
+func TestMergePullRequest(t *testing.T) {
+	var request map[string]string
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/repos/o/r/pulls/7/merge" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		w.Write([]byte(`{"sha": "def456", "merged": true, "message": "Pull Request successfully merged"}`))
+	})
+
+	result, err := client.MergePullRequest("o", "r", 7, MergeOptions{
+		Method: MergeMethodSquash, CommitTitle: "Add feature (#7)", CommitMessage: "Details", SHA: "abc123",
+	})
+	if err != nil {
+		t.Fatalf("MergePullRequest() error = %v", err)
+	}
+	if result.SHA != "def456" {
+		t.Errorf("SHA = %q, want def456", result.SHA)
+	}
+	want := map[string]string{
+		"merge_method": "squash", "commit_title": "Add feature (#7)", "commit_message": "Details", "sha": "abc123",
+	}
+	if !reflect.DeepEqual(request, want) {
+		t.Errorf("request = %v, want %v", request, want)
+	}
+}
+
+func TestMergePullRequestConflict(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"message": "Head branch was modified. Review and try the merge again."}`))
+	})
+
+	_, err := client.MergePullRequest("o", "r", 7, MergeOptions{Method: MergeMethodMerge, SHA: "abc123"})
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) || !strings.Contains(conflict.Message, "Head branch was modified") {
+		t.Errorf("MergePullRequest() error = %v, want *ConflictError", err)
+	}
+}
+
+func TestMergeRequirements(t *testing.T) {
+	responses := map[string]struct {
+		status int
+		body   string
+	}{
+		"/repos/o/r/branches/main/protection": {http.StatusOK, `{
+			"required_pull_request_reviews": {"required_approving_review_count": 2},
+			"required_status_checks": {"strict": true, "checks": [{"context": "build"}]}}`},
+		"/repos/o/r/branches/dev/protection":     {http.StatusNotFound, `{"message": "Branch not protected"}`},
+		"/repos/o/r/branches/release/protection": {http.StatusForbidden, `{"message": "Must have admin rights to Repository."}`},
+	}
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		response := responses[r.URL.Path]
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(response.status)
+		w.Write([]byte(response.body))
+	})
+
+	requirements, err := client.MergeRequirements("o", "r", "main")
+	want := &MergeRequirements{RequiredApprovals: 2, RequiredChecks: []string{"build"}, Strict: true}
+	if err != nil || !reflect.DeepEqual(requirements, want) {
+		t.Errorf("MergeRequirements(main) = %+v, %v, want %+v", requirements, err, want)
+	}
+
+	requirements, err = client.MergeRequirements("o", "r", "dev")
+	if err != nil || !reflect.DeepEqual(requirements, &MergeRequirements{}) {
+		t.Errorf("MergeRequirements(dev) = %+v, %v, want no requirements", requirements, err)
+	}
+
+	// Without admin rights the rules are unknown, so failing checks block
+	pr := &PullRequestDetail{PullRequest: &PullRequest{State: "open", Base: "release", MergeableState: "unstable"},
+		Checks: []*CheckResult{{Name: "lint", State: "failure"}}}
+	check, err := client.CheckMerge("o", "r", pr)
+	if err != nil {
+		t.Fatalf("CheckMerge() error = %v", err)
+	}
+	if check.Ready() {
+		t.Error("CheckMerge() is ready despite a failing check")
+	}
+}
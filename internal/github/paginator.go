@@ -0,0 +1,122 @@
+/*
+ * GitHubber - GitHub API Pagination
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Generic lazy paginator following the API's Link headers
+ */
+
+package github
+
+import (
+	"github.com/google/go-github/v66/github"
+)
+
+// defaultPerPage is the page size requested from the API, its maximum
+const defaultPerPage = 100
+
+// PageFunc fetches the page selected by opts. The returned response's
+// NextPage, parsed from the Link header, is 0 on the last page.
+type PageFunc[T any] func(opts github.ListOptions) ([]T, *github.Response, error)
+
+// Paginator lazily walks every page of a list endpoint. Pages are only
+// requested once the items already fetched have been consumed.
+type Paginator[T any] struct {
+	fetch    PageFunc[T]
+	maxItems int
+	nextPage int
+	started  bool
+	buffer   []T
+	returned int
+	item     T
+	err      error
+}
+
+// NewPaginator returns a paginator over fetch that stops after maxItems
+// items; maxItems <= 0 means no limit
+func NewPaginator[T any](fetch PageFunc[T], maxItems int) *Paginator[T] {
+	return &Paginator[T]{fetch: fetch, maxItems: maxItems, nextPage: 1}
+}
+
+// Limit caps the number of items the paginator returns; n <= 0 removes the cap
+func (p *Paginator[T]) Limit(n int) *Paginator[T] {
+	p.maxItems = n
+	return p
+}
+
+// Next advances to the next item, fetching the next page when needed, and
+// reports whether there is one. Check Err once Next returns false.
+func (p *Paginator[T]) Next() bool {
+	if p.err != nil || (p.maxItems > 0 && p.returned >= p.maxItems) {
+		return false
+	}
+
+	for len(p.buffer) == 0 {
+		if p.started && p.nextPage == 0 {
+			return false
+		}
+		p.started = true
+
+		items, resp, err := p.fetch(github.ListOptions{Page: p.nextPage, PerPage: defaultPerPage})
+		if err != nil {
+			p.err = err
+			return false
+		}
+		p.buffer = items
+		p.nextPage = 0
+		if resp != nil {
+			p.nextPage = resp.NextPage
+		}
+	}
+
+	p.item, p.buffer = p.buffer[0], p.buffer[1:]
+	p.returned++
+	return true
+}
+
+// Item returns the current item
+func (p *Paginator[T]) Item() T {
+	return p.item
+}
+
+// Err returns the error that stopped the iteration, if any
+func (p *Paginator[T]) Err() error {
+	return p.err
+}
+
+// Take returns up to n further items
+func (p *Paginator[T]) Take(n int) ([]T, error) {
+	var items []T
+	for len(items) < n && p.Next() {
+		items = append(items, p.Item())
+	}
+	return items, p.err
+}
+
+// All returns every remaining item
+func (p *Paginator[T]) All() ([]T, error) {
+	var items []T
+	for p.Next() {
+		items = append(items, p.Item())
+	}
+	return items, p.err
+}
+
+// Done reports whether the paginator has no further items. It may fetch
+// the next page to find out.
+func (p *Paginator[T]) Done() bool {
+	if p.err != nil || (p.maxItems > 0 && p.returned >= p.maxItems) {
+		return true
+	}
+	if len(p.buffer) > 0 {
+		return false
+	}
+	if p.started && p.nextPage == 0 {
+		return true
+	}
+	if !p.Next() {
+		return true
+	}
+	// Put the prefetched item back
+	p.buffer = append([]T{p.item}, p.buffer...)
+	p.returned--
+	return false
+}
@@ -0,0 +1,77 @@
+package github
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// fakePages serves items in pages of size and records the requested pages
+func fakePages(items []int, size int, requested *[]int) PageFunc[int] {
+	return func(opts github.ListOptions) ([]int, *github.Response, error) {
+		*requested = append(*requested, opts.Page)
+		start := (opts.Page - 1) * size
+		end := start + size
+		resp := &github.Response{NextPage: opts.Page + 1}
+		if end >= len(items) {
+			end = len(items)
+			resp.NextPage = 0
+		}
+		return items[start:end], resp, nil
+	}
+}
+
+func TestPaginatorAll(t *testing.T) {
+	var requested []int
+	p := NewPaginator(fakePages([]int{1, 2, 3, 4, 5, 6, 7}, 3, &requested), 0)
+
+	items, err := p.All()
+	if err != nil {
+		t.Fatalf("All() error = %v", err)
+	}
+	if !reflect.DeepEqual(items, []int{1, 2, 3, 4, 5, 6, 7}) {
+		t.Errorf("All() = %v", items)
+	}
+	if !reflect.DeepEqual(requested, []int{1, 2, 3}) {
+		t.Errorf("requested pages = %v, want [1 2 3]", requested)
+	}
+}
+
+func TestPaginatorLazyTake(t *testing.T) {
+	var requested []int
+	p := NewPaginator(fakePages([]int{1, 2, 3, 4, 5, 6, 7}, 3, &requested), 5)
+
+	items, _ := p.Take(2)
+	if !reflect.DeepEqual(items, []int{1, 2}) || len(requested) != 1 {
+		t.Errorf("Take(2) = %v after pages %v, want [1 2] from one page", items, requested)
+	}
+	if p.Done() {
+		t.Error("Done() = true with items remaining")
+	}
+
+	items, _ = p.Take(10)
+	if !reflect.DeepEqual(items, []int{3, 4, 5}) {
+		t.Errorf("Take(10) = %v, want [3 4 5] due to the max items limit", items)
+	}
+	if !p.Done() {
+		t.Error("Done() = false after reaching max items")
+	}
+	if !reflect.DeepEqual(requested, []int{1, 2}) {
+		t.Errorf("requested pages = %v, want [1 2]", requested)
+	}
+}
+
+func TestPaginatorError(t *testing.T) {
+	p := NewPaginator(func(opts github.ListOptions) ([]int, *github.Response, error) {
+		return nil, nil, errors.New("boom")
+	}, 0)
+
+	if p.Next() {
+		t.Error("Next() = true after a failed fetch")
+	}
+	if p.Err() == nil {
+		t.Error("Err() = nil, want fetch error")
+	}
+}
@@ -0,0 +1,415 @@
+/*
+ * GitHubber - GitHub Pull Request Details
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Pull request details, reviews, commits, files, checks and timeline
+ */
+
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// Review is the current review state of one reviewer
+type Review struct {
+	Reviewer    string
+	State       string // APPROVED, CHANGES_REQUESTED, COMMENTED or DISMISSED
+	SubmittedAt time.Time
+}
+
+// PullRequestCommit is a commit on a pull request
+type PullRequestCommit struct {
+	SHA     string
+	Message string // Subject line only
+	Author  string
+	Date    time.Time
+}
+
+// PullRequestFile is a file changed by a pull request
+type PullRequestFile struct {
+	Filename         string
+	PreviousFilename string // Set for renames
+	Status           string // added, removed, modified, renamed, ...
+	Additions        int
+	Deletions        int
+	Patch            string // Unified diff hunks; empty for binary or very large files
+}
+
+// CheckResult is the outcome of a check run or commit status
+type CheckResult struct {
+	Name        string
+	State       string // pending, success, failure, error, neutral, skipped, cancelled, timed_out, action_required
+	Description string
+	URL         string
+}
+
+// TimelineEvent is an entry of a pull request's timeline
+type TimelineEvent struct {
+	Event  string // e.g. commented, reviewed, committed, labeled
+	Actor  string
+	Time   time.Time
+	Body   string // Comment or review text, or the commit subject
+	Detail string // Event specific detail such as the label or review state
+}
+
+// PullRequestDetail is a pull request with everything needed to review it
+type PullRequestDetail struct {
+	*PullRequest
+	Reviews      []*Review // Latest state per reviewer
+	LinkedIssues []string  // Issues closed by the pull request, "#12" or "owner/repo#12"
+	Commits      []*PullRequestCommit
+	Files        []*PullRequestFile
+	Checks       []*CheckResult
+	Timeline     []*TimelineEvent
+}
+
+// newPullRequest converts a go-github pull request
+func newPullRequest(pr *github.PullRequest) *PullRequest {
+	pullRequest := &PullRequest{
+		Number:         pr.GetNumber(),
+		Title:          pr.GetTitle(),
+		State:          pr.GetState(),
+		Author:         pr.GetUser().GetLogin(),
+		URL:            pr.GetHTMLURL(),
+		Body:           pr.GetBody(),
+		Draft:          pr.GetDraft(),
+		Merged:         pr.GetMerged(),
+		Head:           pr.GetHead().GetRef(),
+		HeadRepo:       pr.GetHead().GetRepo().GetFullName(),
+		HeadSHA:        pr.GetHead().GetSHA(),
+		Base:           pr.GetBase().GetRef(),
+		Mergeable:      pr.Mergeable,
+		MergeableState: pr.GetMergeableState(),
+		Commits:        pr.GetCommits(),
+		Additions:      pr.GetAdditions(),
+		Deletions:      pr.GetDeletions(),
+		ChangedFiles:   pr.GetChangedFiles(),
+		CreatedAt:      pr.GetCreatedAt().Time,
+		UpdatedAt:      pr.GetUpdatedAt().Time,
+
+		HeadCloneURL:        pr.GetHead().GetRepo().GetCloneURL(),
+		HeadSSHURL:          pr.GetHead().GetRepo().GetSSHURL(),
+		MaintainerCanModify: pr.GetMaintainerCanModify(),
+	}
+	for _, label := range pr.Labels {
+		pullRequest.Labels = append(pullRequest.Labels, label.GetName())
+	}
+	for _, user := range pr.RequestedReviewers {
+		pullRequest.RequestedReviewers = append(pullRequest.RequestedReviewers, user.GetLogin())
+	}
+	for _, team := range pr.RequestedTeams {
+		pullRequest.RequestedReviewers = append(pullRequest.RequestedReviewers, team.GetOrganization().GetLogin()+"/"+team.GetSlug())
+	}
+	return pullRequest
+}
+
+// GetPullRequest gets a single pull request
+func (c *Client) GetPullRequest(owner, repo string, number int) (*PullRequest, error) {
+	pr, _, err := c.client.PullRequests.Get(c.ctx, owner, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request: %w", classifyError(err))
+	}
+	return newPullRequest(pr), nil
+}
+
+// FindPullRequestForBranch returns the open pull request whose head is
+// head ("owner:branch"), or a *NotFoundError if there is none
+func (c *Client) FindPullRequestForBranch(owner, repo, head string) (*PullRequest, error) {
+	opts := &github.PullRequestListOptions{State: "open", Head: head}
+	prs, _, err := c.client.PullRequests.List(c.ctx, owner, repo, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull requests: %w", classifyError(err))
+	}
+	if len(prs) == 0 {
+		return nil, &NotFoundError{Message: "no open pull request for " + head}
+	}
+	return newPullRequest(prs[0]), nil
+}
+
+// GetPullRequestDetail gets a pull request with its reviews, commits,
+// changed files, check results and timeline
+func (c *Client) GetPullRequestDetail(owner, repo string, number int) (*PullRequestDetail, error) {
+	pr, err := c.GetPullRequest(owner, repo, number)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &PullRequestDetail{PullRequest: pr}
+	if detail.Reviews, err = c.PullRequestReviews(owner, repo, number); err != nil {
+		return nil, err
+	}
+	if detail.Commits, err = c.PullRequestCommits(owner, repo, number); err != nil {
+		return nil, err
+	}
+	if detail.Files, err = c.PullRequestFiles(owner, repo, number); err != nil {
+		return nil, err
+	}
+	if detail.Checks, err = c.CheckResults(owner, repo, pr.HeadSHA); err != nil {
+		return nil, err
+	}
+	if detail.Timeline, err = c.PullRequestTimeline(owner, repo, number); err != nil {
+		return nil, err
+	}
+
+	texts := []string{pr.Body}
+	for _, commit := range detail.Commits {
+		texts = append(texts, commit.Message)
+	}
+	detail.LinkedIssues = linkedIssues(texts...)
+
+	return detail, nil
+}
+
+// PullRequestReviews returns the current review state of each reviewer.
+// Like GitHub, a reviewer's state is their latest approval, change request
+// or dismissal; comments only count when they did nothing else.
+func (c *Client) PullRequestReviews(owner, repo string, number int) ([]*Review, error) {
+	reviews, err := NewPaginator(func(page github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+		reviews, resp, err := c.client.PullRequests.ListReviews(c.ctx, owner, repo, number, &page)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list reviews: %w", classifyError(err))
+		}
+		return reviews, resp, nil
+	}, 0).All()
+	if err != nil {
+		return nil, err
+	}
+	return latestReviews(reviews), nil
+}
+
+// latestReviews reduces reviews, oldest first, to the state of each reviewer
+func latestReviews(reviews []*github.PullRequestReview) []*Review {
+	var order []string
+	latest := make(map[string]*Review)
+	for _, review := range reviews {
+		reviewer := review.GetUser().GetLogin()
+		state := review.GetState()
+		if state == "PENDING" {
+			continue
+		}
+
+		current, seen := latest[reviewer]
+		if !seen {
+			order = append(order, reviewer)
+		}
+		if seen && state == "COMMENTED" && current.State != "COMMENTED" {
+			continue
+		}
+		latest[reviewer] = &Review{Reviewer: reviewer, State: state, SubmittedAt: review.GetSubmittedAt().Time}
+	}
+
+	result := make([]*Review, 0, len(order))
+	for _, reviewer := range order {
+		result = append(result, latest[reviewer])
+	}
+	return result
+}
+
+// PullRequestCommits returns the commits of a pull request, oldest first
+func (c *Client) PullRequestCommits(owner, repo string, number int) ([]*PullRequestCommit, error) {
+	return NewPaginator(func(page github.ListOptions) ([]*PullRequestCommit, *github.Response, error) {
+		commits, resp, err := c.client.PullRequests.ListCommits(c.ctx, owner, repo, number, &page)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list commits: %w", classifyError(err))
+		}
+
+		result := make([]*PullRequestCommit, 0, len(commits))
+		for _, commit := range commits {
+			author := commit.GetAuthor().GetLogin()
+			if author == "" {
+				author = commit.GetCommit().GetAuthor().GetName()
+			}
+			subject, _, _ := strings.Cut(commit.GetCommit().GetMessage(), "\n")
+			result = append(result, &PullRequestCommit{
+				SHA:     commit.GetSHA(),
+				Message: subject,
+				Author:  author,
+				Date:    commit.GetCommit().GetAuthor().GetDate().Time,
+			})
+		}
+		return result, resp, nil
+	}, 0).All()
+}
+
+// PullRequestFiles returns the files changed by a pull request
+func (c *Client) PullRequestFiles(owner, repo string, number int) ([]*PullRequestFile, error) {
+	return NewPaginator(func(page github.ListOptions) ([]*PullRequestFile, *github.Response, error) {
+		files, resp, err := c.client.PullRequests.ListFiles(c.ctx, owner, repo, number, &page)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list files: %w", classifyError(err))
+		}
+
+		result := make([]*PullRequestFile, 0, len(files))
+		for _, file := range files {
+			result = append(result, &PullRequestFile{
+				Filename:         file.GetFilename(),
+				PreviousFilename: file.GetPreviousFilename(),
+				Status:           file.GetStatus(),
+				Additions:        file.GetAdditions(),
+				Deletions:        file.GetDeletions(),
+				Patch:            file.GetPatch(),
+			})
+		}
+		return result, resp, nil
+	}, 0).All()
+}
+
+// CheckResults returns the check runs and commit statuses reported for ref
+func (c *Client) CheckResults(owner, repo, ref string) ([]*CheckResult, error) {
+	runs, err := NewPaginator(func(page github.ListOptions) ([]*CheckResult, *github.Response, error) {
+		opts := &github.ListCheckRunsOptions{ListOptions: page}
+		results, resp, err := c.client.Checks.ListCheckRunsForRef(c.ctx, owner, repo, ref, opts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list check runs: %w", classifyError(err))
+		}
+
+		checks := make([]*CheckResult, 0, len(results.CheckRuns))
+		for _, run := range results.CheckRuns {
+			state := run.GetConclusion()
+			if run.GetStatus() != "completed" {
+				state = "pending"
+			}
+			checks = append(checks, &CheckResult{
+				Name:        run.GetName(),
+				State:       state,
+				Description: run.GetOutput().GetTitle(),
+				URL:         run.GetHTMLURL(),
+			})
+		}
+		return checks, resp, nil
+	}, 0).All()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := NewPaginator(func(page github.ListOptions) ([]*CheckResult, *github.Response, error) {
+		combined, resp, err := c.client.Repositories.GetCombinedStatus(c.ctx, owner, repo, ref, &page)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get commit statuses: %w", classifyError(err))
+		}
+
+		checks := make([]*CheckResult, 0, len(combined.Statuses))
+		for _, status := range combined.Statuses {
+			checks = append(checks, &CheckResult{
+				Name:        status.GetContext(),
+				State:       status.GetState(),
+				Description: status.GetDescription(),
+				URL:         status.GetTargetURL(),
+			})
+		}
+		return checks, resp, nil
+	}, 0).All()
+	if err != nil {
+		return nil, err
+	}
+
+	checks := append(runs, statuses...)
+	sort.SliceStable(checks, func(i, j int) bool { return checks[i].Name < checks[j].Name })
+	return checks, nil
+}
+
+// CombinedCheckState summarizes checks as "failure" if any check failed,
+// "pending" if any is still running, "success" otherwise, or "" without
+// any checks. Neutral and skipped checks count as passing.
+func CombinedCheckState(checks []*CheckResult) string {
+	if len(checks) == 0 {
+		return ""
+	}
+	state := "success"
+	for _, check := range checks {
+		switch check.State {
+		case "failure", "error", "cancelled", "timed_out", "action_required", "stale":
+			return "failure"
+		case "pending", "queued", "in_progress":
+			state = "pending"
+		}
+	}
+	return state
+}
+
+// PullRequestTimeline returns the events of a pull request, oldest first
+func (c *Client) PullRequestTimeline(owner, repo string, number int) ([]*TimelineEvent, error) {
+	return NewPaginator(func(page github.ListOptions) ([]*TimelineEvent, *github.Response, error) {
+		events, resp, err := c.client.Issues.ListIssueTimeline(c.ctx, owner, repo, number, &page)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list timeline: %w", classifyError(err))
+		}
+
+		result := make([]*TimelineEvent, 0, len(events))
+		for _, event := range events {
+			result = append(result, newTimelineEvent(event))
+		}
+		return result, resp, nil
+	}, 0).All()
+}
+
+// newTimelineEvent converts a timeline entry. Which fields GitHub fills in
+// depends on the event type.
+func newTimelineEvent(event *github.Timeline) *TimelineEvent {
+	converted := &TimelineEvent{
+		Event: event.GetEvent(),
+		Actor: event.GetActor().GetLogin(),
+		Time:  event.GetCreatedAt().Time,
+		Body:  event.GetBody(),
+	}
+
+	switch converted.Event {
+	case "committed":
+		converted.Actor = event.GetAuthor().GetName()
+		converted.Time = event.GetAuthor().GetDate().Time
+		converted.Body, _, _ = strings.Cut(event.GetMessage(), "\n")
+		if sha := event.GetSHA(); len(sha) >= 7 {
+			converted.Detail = sha[:7]
+		}
+	case "reviewed":
+		converted.Actor = event.GetUser().GetLogin()
+		converted.Time = event.GetSubmittedAt().Time
+		converted.Detail = strings.ToUpper(event.GetState())
+	case "commented":
+		if converted.Actor == "" {
+			converted.Actor = event.GetUser().GetLogin()
+		}
+	case "labeled", "unlabeled":
+		converted.Detail = event.GetLabel().GetName()
+	case "review_requested", "review_request_removed":
+		converted.Detail = event.GetReviewer().GetLogin()
+		if team := event.GetRequestedTeam(); team != nil {
+			converted.Detail = team.GetSlug()
+		}
+	case "assigned", "unassigned":
+		converted.Detail = event.GetAssignee().GetLogin()
+	case "renamed":
+		converted.Detail = fmt.Sprintf("%s -> %s", event.GetRename().GetFrom(), event.GetRename().GetTo())
+	case "cross-referenced":
+		if issue := event.GetSource().GetIssue(); issue != nil {
+			converted.Actor = event.GetSource().GetActor().GetLogin()
+			converted.Detail = fmt.Sprintf("#%d %s", issue.GetNumber(), issue.GetTitle())
+		}
+	}
+	return converted
+}
+
+// closingKeyword matches GitHub's keywords for closing issues, such as
+// "Fixes #12" or "closes owner/repo#3"
+var closingKeyword = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+((?:[\w.-]+/[\w.-]+)?#\d+)\b`)
+
+// linkedIssues returns the issues referenced with closing keywords in texts
+func linkedIssues(texts ...string) []string {
+	var issues []string
+	seen := make(map[string]bool)
+	for _, text := range texts {
+		for _, match := range closingKeyword.FindAllStringSubmatch(text, -1) {
+			if !seen[match[1]] {
+				seen[match[1]] = true
+				issues = append(issues, match[1])
+			}
+		}
+	}
+	return issues
+}
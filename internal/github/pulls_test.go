@@ -0,0 +1,152 @@
+package github
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v66/github"
+)
+
+func TestLatestReviews(t *testing.T) {
+	review := func(user, state string) *github.PullRequestReview {
+		return &github.PullRequestReview{User: &github.User{Login: github.String(user)}, State: github.String(state)}
+	}
+
+	reviews := latestReviews([]*github.PullRequestReview{
+		review("alice", "CHANGES_REQUESTED"),
+		review("bob", "COMMENTED"),
+		review("alice", "COMMENTED"),
+		review("carol", "PENDING"),
+		review("bob", "APPROVED"),
+		review("alice", "APPROVED"),
+	})
+
+	got := make(map[string]string)
+	for _, r := range reviews {
+		got[r.Reviewer] = r.State
+	}
+	want := map[string]string{"alice": "APPROVED", "bob": "APPROVED"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("latestReviews() = %v, want %v", got, want)
+	}
+
+	reviews = latestReviews([]*github.PullRequestReview{
+		review("alice", "CHANGES_REQUESTED"),
+		review("alice", "COMMENTED"),
+	})
+	if len(reviews) != 1 || reviews[0].State != "CHANGES_REQUESTED" {
+		t.Errorf("a later comment must not clear a change request, got %+v", reviews[0])
+	}
+}
+
+func TestLinkedIssues(t *testing.T) {
+	got := linkedIssues(
+		"This fixes #12 and resolves octo/other#3.\nSee also #99.",
+		"Closes: #12",
+		"fix: typo in README",
+	)
+	want := []string{"#12", "octo/other#3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("linkedIssues() = %v, want %v", got, want)
+	}
+}
+
+func TestCombinedCheckState(t *testing.T) {
+	tests := []struct {
+		states []string
+		want   string
+	}{
+		{nil, ""},
+		{[]string{"success", "neutral", "skipped"}, "success"},
+		{[]string{"success", "in_progress"}, "pending"},
+		{[]string{"pending", "failure", "success"}, "failure"},
+		{[]string{"error"}, "failure"},
+	}
+
+	for _, tt := range tests {
+		var checks []*CheckResult
+		for _, state := range tt.states {
+			checks = append(checks, &CheckResult{State: state})
+		}
+		if got := CombinedCheckState(checks); got != tt.want {
+			t.Errorf("CombinedCheckState(%v) = %q, want %q", tt.states, got, tt.want)
+		}
+	}
+}
+
+func TestGetPullRequestDetail(t *testing.T) {
+	responses := map[string]string{
+		"/repos/o/r/pulls/7": `{"number": 7, "title": "Add feature", "state": "open", "body": "Closes #5",
+			"user": {"login": "alice"}, "mergeable": true, "mergeable_state": "clean", "commits": 1,
+			"head": {"ref": "feature", "sha": "abc123", "repo": {"full_name": "alice/r"}},
+			"base": {"ref": "main"}, "labels": [{"name": "enhancement"}],
+			"requested_reviewers": [{"login": "bob"}], "requested_teams": [{"slug": "core", "organization": {"login": "o"}}]}`,
+		"/repos/o/r/pulls/7/reviews": `[{"user": {"login": "carol"}, "state": "APPROVED"}]`,
+		"/repos/o/r/pulls/7/commits": `[{"sha": "abc123", "commit": {"message": "Fix #6: add feature\n\nDetails", "author": {"name": "Alice"}}}]`,
+		"/repos/o/r/pulls/7/files":   `[{"filename": "main.go", "status": "modified", "additions": 3, "deletions": 1}]`,
+		"/repos/o/r/commits/abc123/check-runs": `{"total_count": 2, "check_runs": [
+			{"name": "test", "status": "completed", "conclusion": "success"},
+			{"name": "build", "status": "in_progress"}]}`,
+		"/repos/o/r/commits/abc123/status": `{"state": "failure", "statuses": [{"context": "lint", "state": "failure"}]}`,
+		"/repos/o/r/issues/7/timeline":     `[{"event": "commented", "actor": {"login": "dave"}, "body": "LGTM"}]`,
+	}
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	})
+
+	detail, err := client.GetPullRequestDetail("o", "r", 7)
+	if err != nil {
+		t.Fatalf("GetPullRequestDetail() error = %v", err)
+	}
+
+	if detail.Head != "feature" || detail.HeadRepo != "alice/r" || detail.Base != "main" || detail.Mergeable == nil || !*detail.Mergeable {
+		t.Errorf("pull request = %+v", detail.PullRequest)
+	}
+	if !reflect.DeepEqual(detail.Labels, []string{"enhancement"}) || !reflect.DeepEqual(detail.RequestedReviewers, []string{"bob", "o/core"}) {
+		t.Errorf("labels = %v, requested reviewers = %v", detail.Labels, detail.RequestedReviewers)
+	}
+	if len(detail.Reviews) != 1 || detail.Reviews[0].Reviewer != "carol" {
+		t.Errorf("reviews = %+v", detail.Reviews)
+	}
+	if len(detail.Commits) != 1 || detail.Commits[0].Message != "Fix #6: add feature" {
+		t.Errorf("commits = %+v", detail.Commits)
+	}
+	if !reflect.DeepEqual(detail.LinkedIssues, []string{"#5", "#6"}) {
+		t.Errorf("linked issues = %v, want [#5 #6]", detail.LinkedIssues)
+	}
+
+	var names []string
+	for _, check := range detail.Checks {
+		names = append(names, check.Name+"="+check.State)
+	}
+	if want := []string{"build=pending", "lint=failure", "test=success"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("checks = %v, want %v", names, want)
+	}
+	if len(detail.Timeline) != 1 || detail.Timeline[0].Actor != "dave" || detail.Timeline[0].Body != "LGTM" {
+		t.Errorf("timeline = %+v", detail.Timeline)
+	}
+}
+
+func TestFindPullRequestForBranchNotFound(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("head"); got != "alice:feature" {
+			t.Errorf("head = %q, want alice:feature", got)
+		}
+		w.Write([]byte(`[]`))
+	})
+
+	_, err := client.FindPullRequestForBranch("o", "r", "alice:feature")
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("FindPullRequestForBranch() error = %v, want *NotFoundError", err)
+	}
+}
@@ -0,0 +1,139 @@
+/*
+ * GitHubber - GitHub API Retries
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: HTTP transport retrying transient failures with exponential backoff
+ */
+
+package github
+
+import (
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMaxRetries = 3
+	defaultRetryDelay = time.Second
+	maxRetryWait      = time.Minute
+)
+
+// retryTransport retries requests that failed with a transient server error
+// or hit a secondary rate limit, backing off exponentially between attempts.
+// Server errors are only retried for idempotent methods, since the request
+// may already have been processed.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	baseDelay  time.Duration
+	sleep      func(time.Duration)
+}
+
+func newRetryTransport(base http.RoundTripper) *retryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &retryTransport{
+		base:       base,
+		maxRetries: defaultMaxRetries,
+		baseDelay:  defaultRetryDelay,
+		sleep:      time.Sleep,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+
+		wait, retry := t.retryDelay(req, resp, err, attempt)
+		if !retry || attempt >= t.maxRetries || wait > maxRetryWait {
+			return resp, err
+		}
+		if req.Body != nil {
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		default:
+		}
+		t.sleep(wait)
+	}
+}
+
+// retryDelay decides whether a response should be retried and how long to
+// wait first
+func (t *retryTransport) retryDelay(req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	backoff := t.baseDelay << attempt
+	backoff += time.Duration(rand.Int63n(int64(t.baseDelay)/2 + 1))
+
+	if err != nil {
+		return backoff, isIdempotent(req.Method)
+	}
+
+	switch {
+	case isSecondaryRateLimit(resp):
+		if wait, ok := retryAfter(resp); ok {
+			return wait, true
+		}
+		return backoff, true
+	case resp.StatusCode == http.StatusBadGateway || resp.StatusCode == http.StatusServiceUnavailable ||
+		resp.StatusCode == http.StatusGatewayTimeout || resp.StatusCode == http.StatusInternalServerError:
+		return backoff, isIdempotent(req.Method)
+	}
+	return 0, false
+}
+
+// isSecondaryRateLimit reports whether resp rejects the request because of a
+// secondary rate limit. Primary rate limit responses report no remaining
+// requests and are not retried since the reset may be far away.
+func isSecondaryRateLimit(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+	if resp.Header.Get("Retry-After") != "" {
+		return true
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		return false
+	}
+
+	// Peek at the body without consuming it for the caller
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(strings.NewReader(string(body)))
+	return err == nil && strings.Contains(strings.ToLower(string(body)), "secondary rate limit")
+}
+
+// retryAfter parses the Retry-After header given in seconds
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
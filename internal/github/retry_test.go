@@ -0,0 +1,179 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// newTestClient returns a client talking to handler through a retry
+// transport that records its waits instead of sleeping
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, *[]time.Duration) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	var waits []time.Duration
+	transport := newRetryTransport(http.DefaultTransport)
+	transport.sleep = func(d time.Duration) { waits = append(waits, d) }
+
+	client := github.NewClient(&http.Client{Transport: transport})
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	return &Client{client: client, ctx: context.Background()}, &waits
+}
+
+func TestRetryTransientServerError(t *testing.T) {
+	attempts := 0
+	client, waits := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `{"name": "repo", "owner": {"login": "owner"}}`)
+	})
+
+	repo, err := client.GetRepository("owner", "repo")
+	if err != nil {
+		t.Fatalf("GetRepository() error = %v", err)
+	}
+	if repo.Name != "repo" || attempts != 3 {
+		t.Errorf("got repo %q after %d attempts, want repo after 3", repo.Name, attempts)
+	}
+	if len(*waits) != 2 || (*waits)[1] <= (*waits)[0] {
+		t.Errorf("waits = %v, want two increasing backoffs", *waits)
+	}
+}
+
+func TestRetryDoesNotRepeatFailedPost(t *testing.T) {
+	attempts := 0
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	if _, err := client.CreatePullRequest("owner", "repo", "title", "body", "head", "main"); err == nil {
+		t.Fatal("CreatePullRequest() succeeded, want error")
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+}
+
+func TestRetrySecondaryRateLimit(t *testing.T) {
+	var bodies []string
+	client, waits := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message": "You have exceeded a secondary rate limit"}`)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"number": 5}`)
+	})
+
+	pr, err := client.CreatePullRequest("owner", "repo", "title", "body", "head", "main")
+	if err != nil {
+		t.Fatalf("CreatePullRequest() error = %v", err)
+	}
+	if pr.Number != 5 {
+		t.Errorf("Number = %d, want 5", pr.Number)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] || !strings.Contains(bodies[1], "title") {
+		t.Errorf("request bodies = %q, want the same body resent", bodies)
+	}
+	if len(*waits) != 1 || (*waits)[0] != 7*time.Second {
+		t.Errorf("waits = %v, want [7s]", *waits)
+	}
+}
+
+func TestTypedErrors(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Unix()
+	tests := []struct {
+		name    string
+		status  int
+		headers map[string]string
+		body    string
+		check   func(error) bool
+	}{
+		{"not found", http.StatusNotFound, nil, `{"message": "Not Found"}`, func(err error) bool {
+			var target *NotFoundError
+			return errors.As(err, &target)
+		}},
+		{"unauthorized", http.StatusUnauthorized, nil, `{"message": "Bad credentials"}`, func(err error) bool {
+			var target *UnauthorizedError
+			return errors.As(err, &target) && !target.Forbidden
+		}},
+		{"forbidden", http.StatusForbidden, nil, `{"message": "Resource not accessible"}`, func(err error) bool {
+			var target *UnauthorizedError
+			return errors.As(err, &target) && target.Forbidden
+		}},
+		{"rate limited", http.StatusForbidden, map[string]string{
+			"X-RateLimit-Limit":     "5000",
+			"X-RateLimit-Remaining": "0",
+			"X-RateLimit-Reset":     fmt.Sprint(reset),
+		}, `{"message": "API rate limit exceeded"}`, func(err error) bool {
+			var target *RateLimitedError
+			return errors.As(err, &target) && !target.Secondary && target.Reset.Unix() == reset
+		}},
+		{"validation", http.StatusUnprocessableEntity, nil,
+			`{"message": "Validation Failed", "errors": [{"resource": "PullRequest", "field": "head", "code": "invalid"}]}`,
+			func(err error) bool {
+				var target *ValidationError
+				return errors.As(err, &target) && len(target.Fields) == 1 && target.Fields[0].Field == "head" &&
+					strings.Contains(err.Error(), "PullRequest.head: invalid")
+			}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				for key, value := range tt.headers {
+					w.Header().Set(key, value)
+				}
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			})
+
+			_, err := client.GetRepository("owner", "repo")
+			if err == nil || !tt.check(err) {
+				t.Errorf("GetRepository() error = %v (%T)", err, errors.Unwrap(err))
+			}
+		})
+	}
+}
+
+func TestRateLimits(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rate_limit" {
+			t.Errorf("path = %s, want /rate_limit", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"resources": {
+			"core": {"limit": 5000, "remaining": 4990, "reset": 1700000000},
+			"search": {"limit": 30, "remaining": 30, "reset": 1700000000}
+		}}`)
+	})
+
+	limits, err := client.RateLimits()
+	if err != nil {
+		t.Fatalf("RateLimits() error = %v", err)
+	}
+	if limits.Core.Limit != 5000 || limits.Core.Remaining != 4990 || limits.Core.Reset.Unix() != 1700000000 {
+		t.Errorf("Core = %+v", limits.Core)
+	}
+	if limits.Search.Remaining != 30 || limits.GraphQL.Limit != 0 {
+		t.Errorf("Search = %+v, GraphQL = %+v", limits.Search, limits.GraphQL)
+	}
+}
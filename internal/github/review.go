@@ -0,0 +1,258 @@
+/*
+ * GitHubber - GitHub Pull Request Reviews
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Diff hunk parsing, review comments and locally saved pending reviews
+ */
+
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// Review events accepted by SubmitReview
+const (
+	ReviewEventApprove        = "APPROVE"
+	ReviewEventRequestChanges = "REQUEST_CHANGES"
+	ReviewEventComment        = "COMMENT"
+)
+
+// Diff sides a review comment can refer to
+const (
+	SideLeft  = "LEFT"  // The base version, for removed lines
+	SideRight = "RIGHT" // The head version, for added and unchanged lines
+)
+
+// DiffLine is a line of a diff hunk
+type DiffLine struct {
+	Kind    byte // '+', '-' or ' '
+	Content string
+	OldLine int // Line number in the base version, 0 for added lines
+	NewLine int // Line number in the head version, 0 for removed lines
+}
+
+// DiffHunk is a hunk of a unified diff
+type DiffHunk struct {
+	Header string // The @@ line
+	Lines  []*DiffLine
+}
+
+// Line returns the line of the hunk with number n on side, or nil
+func (h *DiffHunk) Line(side string, n int) *DiffLine {
+	for _, line := range h.Lines {
+		if side == SideLeft && line.Kind != '+' && line.OldLine == n {
+			return line
+		}
+		if side == SideRight && line.Kind != '-' && line.NewLine == n {
+			return line
+		}
+	}
+	return nil
+}
+
+var hunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// ParsePatch splits the patch of a pull request file into hunks and numbers
+// their lines
+func ParsePatch(patch string) ([]*DiffHunk, error) {
+	var hunks []*DiffHunk
+	var hunk *DiffHunk
+	oldLine, newLine := 0, 0
+
+	for _, text := range strings.Split(strings.TrimSuffix(patch, "\n"), "\n") {
+		if match := hunkHeader.FindStringSubmatch(text); match != nil {
+			oldLine, _ = strconv.Atoi(match[1])
+			newLine, _ = strconv.Atoi(match[2])
+			hunk = &DiffHunk{Header: text}
+			hunks = append(hunks, hunk)
+			continue
+		}
+		if hunk == nil {
+			if text == "" {
+				continue
+			}
+			return nil, fmt.Errorf("invalid patch: expected a hunk header, got %q", text)
+		}
+		if strings.HasPrefix(text, `\`) {
+			// "\ No newline at end of file"
+			continue
+		}
+
+		line := &DiffLine{Kind: ' ', Content: text}
+		if text != "" {
+			line.Kind, line.Content = text[0], text[1:]
+		}
+		switch line.Kind {
+		case '+':
+			line.NewLine = newLine
+			newLine++
+		case '-':
+			line.OldLine = oldLine
+			oldLine++
+		case ' ':
+			line.OldLine, line.NewLine = oldLine, newLine
+			oldLine++
+			newLine++
+		default:
+			return nil, fmt.Errorf("invalid patch line %q", text)
+		}
+		hunk.Lines = append(hunk.Lines, line)
+	}
+	return hunks, nil
+}
+
+// ReviewComment is a comment on one or more lines of a pull request file
+type ReviewComment struct {
+	Path      string `json:"path"`
+	Side      string `json:"side"`
+	Line      int    `json:"line"`                 // Last line commented on
+	StartLine int    `json:"start_line,omitempty"` // First line of a multi-line comment
+	Body      string `json:"body"`
+}
+
+// Suggestion formats a comment proposing replacement for the commented
+// lines, which GitHub lets the author apply with one click. An empty
+// replacement suggests deleting the lines.
+func Suggestion(comment, replacement string) string {
+	suggestion := "```suggestion\n```"
+	if replacement != "" {
+		suggestion = "```suggestion\n" + replacement + "\n```"
+	}
+	if comment = strings.TrimSpace(comment); comment != "" {
+		return comment + "\n\n" + suggestion
+	}
+	return suggestion
+}
+
+// SubmitReview submits a review with all its line comments at once. The
+// comments refer to the diff at commitID.
+func (c *Client) SubmitReview(owner, repo string, number int, commitID, event, body string, comments []*ReviewComment) (*Review, error) {
+	request := &github.PullRequestReviewRequest{
+		Event: github.String(event),
+	}
+	if commitID != "" {
+		request.CommitID = github.String(commitID)
+	}
+	if body != "" {
+		request.Body = github.String(body)
+	}
+	for _, comment := range comments {
+		draft := &github.DraftReviewComment{
+			Path: github.String(comment.Path),
+			Body: github.String(comment.Body),
+			Side: github.String(comment.Side),
+			Line: github.Int(comment.Line),
+		}
+		if comment.StartLine != 0 && comment.StartLine != comment.Line {
+			draft.StartLine = github.Int(comment.StartLine)
+			draft.StartSide = github.String(comment.Side)
+		}
+		request.Comments = append(request.Comments, draft)
+	}
+
+	review, _, err := c.client.PullRequests.CreateReview(c.ctx, owner, repo, number, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit review: %w", classifyError(err))
+	}
+
+	return &Review{
+		Reviewer:    review.GetUser().GetLogin(),
+		State:       review.GetState(),
+		SubmittedAt: review.GetSubmittedAt().Time,
+	}, nil
+}
+
+// PendingReview is a review that has not been submitted yet. It is saved
+// locally so a review can be resumed later.
+type PendingReview struct {
+	Host      string           `json:"host"`
+	Owner     string           `json:"owner"`
+	Repo      string           `json:"repo"`
+	Number    int              `json:"number"`
+	CommitID  string           `json:"commit_id"` // Head commit the comments were written against
+	Body      string           `json:"body,omitempty"`
+	Comments  []*ReviewComment `json:"comments"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// pendingReviewPath returns where the pending review of a pull request is
+// saved under dir
+func pendingReviewPath(dir string, repo *RepoRef, number int) string {
+	return filepath.Join(dir, repo.Host, repo.Owner, repo.Name, strconv.Itoa(number)+".json")
+}
+
+// LoadPendingReview returns the saved pending review of a pull request, or
+// a new one for commitID if none was saved. found reports which it was.
+func LoadPendingReview(dir string, repo *RepoRef, number int, commitID string) (review *PendingReview, found bool, err error) {
+	data, err := os.ReadFile(pendingReviewPath(dir, repo, number))
+	if errors.Is(err, os.ErrNotExist) {
+		return &PendingReview{
+			Host:     repo.Host,
+			Owner:    repo.Owner,
+			Repo:     repo.Name,
+			Number:   number,
+			CommitID: commitID,
+		}, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read pending review: %w", err)
+	}
+
+	review = &PendingReview{}
+	if err := json.Unmarshal(data, review); err != nil {
+		return nil, false, fmt.Errorf("failed to parse pending review: %w", err)
+	}
+	return review, true, nil
+}
+
+// Save stores the pending review under dir
+func (r *PendingReview) Save(dir string) error {
+	path := pendingReviewPath(dir, r.ref(), r.Number)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create reviews directory: %w", err)
+	}
+
+	r.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode pending review: %w", err)
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		return fmt.Errorf("failed to save pending review: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the saved pending review from dir
+func (r *PendingReview) Delete(dir string) error {
+	err := os.Remove(pendingReviewPath(dir, r.ref(), r.Number))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete pending review: %w", err)
+	}
+	return nil
+}
+
+func (r *PendingReview) ref() *RepoRef {
+	return &RepoRef{Host: r.Host, Owner: r.Owner, Name: r.Repo}
+}
+
+// FileComments returns the pending comments on path
+func (r *PendingReview) FileComments(path string) []*ReviewComment {
+	var comments []*ReviewComment
+	for _, comment := range r.Comments {
+		if comment.Path == path {
+			comments = append(comments, comment)
+		}
+	}
+	return comments
+}
@@ -0,0 +1,146 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const testPatch = `@@ -1,4 +1,5 @@
+ package main
+-import "fmt"
++import (
++	"fmt"
++)
+ 
+@@ -10,2 +11,3 @@ func main() {
+ 	fmt.Println("hi")
++	fmt.Println("bye")
+ }
+\ No newline at end of file`
+
+func TestParsePatch(t *testing.T) {
+	hunks, err := ParsePatch(testPatch)
+	if err != nil {
+		t.Fatalf("ParsePatch() error = %v", err)
+	}
+	if len(hunks) != 2 {
+		t.Fatalf("ParsePatch() returned %d hunks, want 2", len(hunks))
+	}
+
+	var got []string
+	for _, line := range hunks[0].Lines {
+		got = append(got, fmt.Sprintf("%c %d %d", line.Kind, line.OldLine, line.NewLine))
+	}
+	want := []string{"  1 1", "- 2 0", "+ 0 2", "+ 0 3", "+ 0 4", "  3 5"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("first hunk lines = %q, want %q", got, want)
+	}
+	if hunks[0].Lines[5].Content != "" {
+		t.Errorf("empty context line content = %q", hunks[0].Lines[5].Content)
+	}
+
+	second := hunks[1]
+	if second.Header != "@@ -10,2 +11,3 @@ func main() {" || len(second.Lines) != 3 {
+		t.Errorf("second hunk = %q with %d lines", second.Header, len(second.Lines))
+	}
+	if line := second.Line(SideRight, 12); line == nil || line.Content != "\tfmt.Println(\"bye\")" {
+		t.Errorf("Line(RIGHT, 12) = %+v", line)
+	}
+	if line := second.Line(SideLeft, 11); line == nil || line.NewLine != 13 {
+		t.Errorf("Line(LEFT, 11) = %+v, want the closing brace", line)
+	}
+	if hunks[0].Line(SideRight, 1) == nil || hunks[0].Line(SideLeft, 2) == nil || hunks[0].Line(SideRight, 9) != nil {
+		t.Error("Line() does not match the hunk's line numbers")
+	}
+
+	if _, err := ParsePatch("not a patch"); err == nil {
+		t.Error("ParsePatch() should reject text without a hunk header")
+	}
+}
+
+func TestSuggestion(t *testing.T) {
+	if got, want := Suggestion("Use a constant", "\tconst x = 1"), "Use a constant\n\n```suggestion\n\tconst x = 1\n```"; got != want {
+		t.Errorf("Suggestion() = %q, want %q", got, want)
+	}
+	if got, want := Suggestion("", ""), "```suggestion\n```"; got != want {
+		t.Errorf("Suggestion() deleting lines = %q, want %q", got, want)
+	}
+}
+
+func TestSubmitReview(t *testing.T) {
+	var request map[string]any
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/o/r/pulls/7/reviews" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		w.Write([]byte(`{"user": {"login": "alice"}, "state": "CHANGES_REQUESTED"}`))
+	})
+
+	review, err := client.SubmitReview("o", "r", 7, "abc123", ReviewEventRequestChanges, "Needs work", []*ReviewComment{
+		{Path: "main.go", Side: SideRight, Line: 12, Body: "Typo"},
+		{Path: "main.go", Side: SideLeft, StartLine: 2, Line: 4, Body: "Keep this"},
+	})
+	if err != nil {
+		t.Fatalf("SubmitReview() error = %v", err)
+	}
+	if review.Reviewer != "alice" || review.State != "CHANGES_REQUESTED" {
+		t.Errorf("SubmitReview() = %+v", review)
+	}
+
+	want := map[string]any{
+		"commit_id": "abc123",
+		"event":     "REQUEST_CHANGES",
+		"body":      "Needs work",
+		"comments": []any{
+			map[string]any{"path": "main.go", "side": "RIGHT", "line": 12.0, "body": "Typo"},
+			map[string]any{"path": "main.go", "side": "LEFT", "start_side": "LEFT", "start_line": 2.0, "line": 4.0, "body": "Keep this"},
+		},
+	}
+	if !reflect.DeepEqual(request, want) {
+		t.Errorf("request = %v, want %v", request, want)
+	}
+}
+
+func TestPendingReview(t *testing.T) {
+	dir := t.TempDir()
+	repo := &RepoRef{Host: "github.com", Owner: "o", Name: "r"}
+
+	review, found, err := LoadPendingReview(dir, repo, 7, "abc123")
+	if err != nil || found {
+		t.Fatalf("LoadPendingReview() = %v, %v, want a new review", found, err)
+	}
+	if review.CommitID != "abc123" || review.Number != 7 {
+		t.Errorf("new review = %+v", review)
+	}
+
+	review.Body = "Looks good"
+	review.Comments = append(review.Comments, &ReviewComment{Path: "main.go", Side: SideRight, Line: 3, Body: "Nit"})
+	if err := review.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, found, err := LoadPendingReview(dir, repo, 7, "def456")
+	if err != nil || !found {
+		t.Fatalf("LoadPendingReview() after Save = %v, %v", found, err)
+	}
+	if loaded.CommitID != "abc123" || loaded.Body != "Looks good" || !reflect.DeepEqual(loaded.Comments, review.Comments) {
+		t.Errorf("loaded review = %+v, want %+v", loaded, review)
+	}
+	if comments := loaded.FileComments("main.go"); len(comments) != 1 {
+		t.Errorf("FileComments() = %v", comments)
+	}
+	if _, found, _ := LoadPendingReview(dir, repo, 8, ""); found {
+		t.Error("pending reviews of other pull requests should be separate")
+	}
+
+	if err := loaded.Delete(dir); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, found, _ := LoadPendingReview(dir, repo, 7, ""); found {
+		t.Error("review still found after Delete()")
+	}
+}
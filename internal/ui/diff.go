@@ -0,0 +1,70 @@
+/*
+ * GitHubber - UI Diff Display
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Rendering of diffs and diff statistics
+ */
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	DiffAddedStyle   = lipgloss.NewStyle().Foreground(accentColor)
+	DiffRemovedStyle = lipgloss.NewStyle().Foreground(errorColor)
+	DiffHunkStyle    = lipgloss.NewStyle().Foreground(primaryColor)
+	DiffGutterStyle  = lipgloss.NewStyle().Foreground(mutedColor)
+)
+
+// FormatDiffstat renders added and removed line counts followed by a bar of
+// at most width '+' and '-' characters, like git diff --stat
+func FormatDiffstat(additions, deletions, width int) string {
+	total := additions + deletions
+	plus, minus := additions, deletions
+	if total > width {
+		plus = additions * width / total
+		minus = deletions * width / total
+		// Keep at least one mark for any change
+		if additions > 0 && plus == 0 {
+			plus = 1
+		}
+		if deletions > 0 && minus == 0 {
+			minus = 1
+		}
+	}
+
+	return fmt.Sprintf("%s %s %s",
+		DiffAddedStyle.Render(fmt.Sprintf("+%d", additions)),
+		DiffRemovedStyle.Render(fmt.Sprintf("-%d", deletions)),
+		DiffAddedStyle.Render(strings.Repeat("+", plus))+DiffRemovedStyle.Render(strings.Repeat("-", minus)))
+}
+
+// FormatDiffHunkHeader renders the @@ header line of a diff hunk
+func FormatDiffHunkHeader(header string) string {
+	return DiffHunkStyle.Render(header)
+}
+
+// FormatDiffLine renders a diff line, including its '+', '-' or ' ' prefix,
+// after a gutter with its old and new line numbers. Zero numbers are left
+// blank.
+func FormatDiffLine(oldLine, newLine int, line string) string {
+	number := func(n int) string {
+		if n == 0 {
+			return "    "
+		}
+		return fmt.Sprintf("%4d", n)
+	}
+	gutter := DiffGutterStyle.Render(number(oldLine) + " " + number(newLine) + " │")
+
+	switch {
+	case strings.HasPrefix(line, "+"):
+		line = DiffAddedStyle.Render(line)
+	case strings.HasPrefix(line, "-"):
+		line = DiffRemovedStyle.Render(line)
+	}
+	return gutter + line
+}
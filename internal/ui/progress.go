@@ -0,0 +1,35 @@
+/*
+ * GitHubber - UI Progress Display
+ * Author: Ritankar Saha <ritankar.saha786@gmail.com>
+ * Description: Progress bar rendering for long running operations
+ */
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	ProgressFilledStyle = lipgloss.NewStyle().Foreground(accentColor)
+	ProgressEmptyStyle  = lipgloss.NewStyle().Foreground(mutedColor)
+)
+
+// FormatProgressBar renders a progress bar of the given width for percent
+// (0-100), followed by the label
+func FormatProgressBar(label string, percent, width int) string {
+	if percent < 0 {
+		percent = 0
+	}
+	if percent > 100 {
+		percent = 100
+	}
+	filled := width * percent / 100
+
+	bar := ProgressFilledStyle.Render(strings.Repeat("█", filled)) +
+		ProgressEmptyStyle.Render(strings.Repeat("░", width-filled))
+	return fmt.Sprintf("%s %3d%% %s", bar, percent, label)
+}